@@ -0,0 +1,186 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// The filters in this file estimate the attitude of a body from gyroscope,
+// accelerometer, and magnetometer samples. The attitude is a unit Hamilton
+// value Q that rotates body vectors into the earth frame, in which z points
+// up and the horizontal component of the magnetic field points along x. The
+// gyroscope measures the angular velocity ω of the body (in radians per
+// second, in the body frame), so that Q evolves by dQ/dt = ½Q*ω. At rest, the
+// accelerometer measures the body components of the up direction (in any
+// units). A zero magnetometer sample means that none is available, in which
+// case the heading is not corrected.
+
+// An AttitudeFilter estimates an attitude from sensor samples.
+type AttitudeFilter interface {
+	// Update advances the estimate by the time step dt (in seconds) with a
+	// gyroscope, accelerometer, and magnetometer sample.
+	Update(gyro, accel, mag Vec3, dt float64)
+	// Attitude returns the current estimate.
+	Attitude() *Hamilton
+}
+
+// earthField rotates the magnetometer sample m into the earth frame with the
+// attitude q, and returns its earth-frame components reduced to the north-up
+// plane (bx, 0, bz): the horizontal magnitude bx and the vertical component
+// bz.
+func earthField(q *Hamilton, m Vec3) (bx, bz float64) {
+	h := q.Rotate(m)
+	return math.Hypot(h[0], h[1]), h[2]
+}
+
+// A MadgwickFilter is the gradient descent orientation filter of Madgwick.
+// Each step integrates the gyroscope and then moves against the normalized
+// gradient of the mismatch between the measured and predicted directions of
+// gravity and of the magnetic field, scaled by the gain Beta (in radians per
+// second).
+type MadgwickFilter struct {
+	Q    *Hamilton
+	Beta float64
+}
+
+// NewMadgwickFilter returns a MadgwickFilter with gain beta, starting from the
+// identity attitude.
+func NewMadgwickFilter(beta float64) *MadgwickFilter {
+	return &MadgwickFilter{Q: NewHamilton(1, 0, 0, 0), Beta: beta}
+}
+
+// Attitude returns the current estimate of f.
+func (f *MadgwickFilter) Attitude() *Hamilton {
+	return new(Hamilton).Copy(f.Q)
+}
+
+// Update advances f by dt with a sensor sample.
+func (f *MadgwickFilter) Update(gyro, accel, mag Vec3, dt float64) {
+	q1, q2, q3, q4 := f.Q.Cartesian()
+	d := NewHamilton(0, gyro[0]/2, gyro[1]/2, gyro[2]/2)
+	d.Mul(f.Q, d)
+	if accel.Norm() > 0 {
+		a := accel.Unit()
+		fg := [3]float64{
+			2*(q2*q4-q1*q3) - a[0],
+			2*(q1*q2+q3*q4) - a[1],
+			2*(0.5-q2*q2-q3*q3) - a[2],
+		}
+		jg := [3][4]float64{
+			{-2 * q3, 2 * q4, -2 * q1, 2 * q2},
+			{2 * q2, 2 * q1, 2 * q4, 2 * q3},
+			{0, -4 * q2, -4 * q3, 0},
+		}
+		var g [4]float64
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 4; j++ {
+				g[j] += jg[i][j] * fg[i]
+			}
+		}
+		if mag.Norm() > 0 {
+			m := mag.Unit()
+			bx, bz := earthField(f.Q, m)
+			fb := [3]float64{
+				2*bx*(0.5-q3*q3-q4*q4) + 2*bz*(q2*q4-q1*q3) - m[0],
+				2*bx*(q2*q3-q1*q4) + 2*bz*(q1*q2+q3*q4) - m[1],
+				2*bx*(q1*q3+q2*q4) + 2*bz*(0.5-q2*q2-q3*q3) - m[2],
+			}
+			jb := [3][4]float64{
+				{-2 * bz * q3, 2 * bz * q4, -4*bx*q3 - 2*bz*q1, -4*bx*q4 + 2*bz*q2},
+				{-2*bx*q4 + 2*bz*q2, 2*bx*q3 + 2*bz*q1, 2*bx*q2 + 2*bz*q4, -2*bx*q1 + 2*bz*q3},
+				{2 * bx * q3, 2*bx*q4 - 4*bz*q2, 2*bx*q1 - 4*bz*q3, 2 * bx * q2},
+			}
+			for i := 0; i < 3; i++ {
+				for j := 0; j < 4; j++ {
+					g[j] += jb[i][j] * fb[i]
+				}
+			}
+		}
+		if n := math.Sqrt(g[0]*g[0] + g[1]*g[1] + g[2]*g[2] + g[3]*g[3]); n > 0 {
+			s := -f.Beta / n
+			d.Add(d, NewHamilton(s*g[0], s*g[1], s*g[2], s*g[3]))
+		}
+	}
+	f.Q.Add(f.Q, d.Dil(d, dt))
+	f.Q.Normalize(f.Q)
+}
+
+// A MahonyFilter is the nonlinear complementary filter of Mahony. The error
+// between the measured and predicted directions of gravity and of the
+// magnetic field drives a proportional-integral correction of the angular
+// velocity, with gains Kp and Ki. The integral term estimates the gyroscope
+// bias.
+type MahonyFilter struct {
+	Q        *Hamilton
+	Kp, Ki   float64
+	Integral Vec3
+}
+
+// NewMahonyFilter returns a MahonyFilter with gains kp and ki, starting from
+// the identity attitude.
+func NewMahonyFilter(kp, ki float64) *MahonyFilter {
+	return &MahonyFilter{Q: NewHamilton(1, 0, 0, 0), Kp: kp, Ki: ki}
+}
+
+// Attitude returns the current estimate of f.
+func (f *MahonyFilter) Attitude() *Hamilton {
+	return new(Hamilton).Copy(f.Q)
+}
+
+// Update advances f by dt with a sensor sample.
+func (f *MahonyFilter) Update(gyro, accel, mag Vec3, dt float64) {
+	inv := new(Hamilton).Conj(f.Q)
+	var e Vec3
+	if accel.Norm() > 0 {
+		v := inv.Rotate(Vec3{0, 0, 1})
+		e = e.Add(accel.Unit().Cross(v))
+	}
+	if mag.Norm() > 0 {
+		m := mag.Unit()
+		bx, bz := earthField(f.Q, m)
+		w := inv.Rotate(Vec3{bx, 0, bz})
+		e = e.Add(m.Cross(w))
+	}
+	if f.Ki > 0 {
+		f.Integral = f.Integral.Add(e.Scale(f.Ki * dt))
+	}
+	ω := gyro.Add(e.Scale(f.Kp)).Add(f.Integral)
+	f.Q = ExpStep(f.Q, ω, dt)
+}
+
+// A ComplementaryFilter integrates the gyroscope and then rotates the
+// attitude towards the tilt given by the accelerometer and the heading given
+// by the magnetometer, by the fraction Alpha of the error at each step.
+type ComplementaryFilter struct {
+	Q     *Hamilton
+	Alpha float64
+}
+
+// NewComplementaryFilter returns a ComplementaryFilter with gain alpha,
+// starting from the identity attitude.
+func NewComplementaryFilter(alpha float64) *ComplementaryFilter {
+	return &ComplementaryFilter{Q: NewHamilton(1, 0, 0, 0), Alpha: alpha}
+}
+
+// Attitude returns the current estimate of f.
+func (f *ComplementaryFilter) Attitude() *Hamilton {
+	return new(Hamilton).Copy(f.Q)
+}
+
+// Update advances f by dt with a sensor sample.
+func (f *ComplementaryFilter) Update(gyro, accel, mag Vec3, dt float64) {
+	q := ExpStep(f.Q, gyro, dt)
+	one := NewHamilton(1, 0, 0, 0)
+	if accel.Norm() > 0 {
+		tilt := rotationBetween(q.Rotate(accel.Unit()), Vec3{0, 0, 1})
+		q.Mul(new(Hamilton).Slerp(one, tilt, f.Alpha), q)
+	}
+	if mag.Norm() > 0 {
+		h := q.Rotate(mag)
+		if math.Hypot(h[0], h[1]) > 0 {
+			heading := RotationHamilton(Vec3{0, 0, 1}, -f.Alpha*math.Atan2(h[1], h[0]))
+			q.Mul(heading, q)
+		}
+	}
+	f.Q = q.Normalize(q)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+// A sensorSample is a synthesized sensor sample together with the true
+// attitude at its time.
+type sensorSample struct {
+	gyro, accel, mag Vec3
+	truth            *Hamilton
+}
+
+// earthMagnetic is the earth-frame direction of the synthesized magnetic
+// field, with a dip of 60° below the horizon.
+var earthMagnetic = Vec3{math.Cos(math.Pi / 3), 0, -math.Sin(math.Pi / 3)}
+
+// replayTrajectory returns n samples spaced by dt of a known trajectory that
+// starts tilted and turns with a smoothly varying body angular velocity. The
+// truth is integrated with many substeps of the exponential map, and the
+// gyroscope reports the true angular velocity plus bias.
+func replayTrajectory(n int, dt float64, bias Vec3) []sensorSample {
+	q := RotationHamilton(Vec3{1, 1, 0}, 0.6)
+	s := make([]sensorSample, n)
+	const substeps = 20
+	for k := range s {
+		t := float64(k) * dt
+		ω := Vec3{0.3 * math.Sin(t), 0.2 * math.Cos(0.7*t), 0.4}
+		for m := 0; m < substeps; m++ {
+			q = ExpStep(q, ω, dt/substeps)
+		}
+		inv := new(Hamilton).Conj(q)
+		s[k] = sensorSample{
+			gyro:  ω.Add(bias),
+			accel: inv.Rotate(Vec3{0, 0, 9.81}),
+			mag:   inv.Rotate(earthMagnetic.Scale(48)),
+			truth: q,
+		}
+	}
+	return s
+}
+
+// replay runs f over the samples, without the magnetometer if useMag is
+// false, and returns the final tilt and heading errors. The tilt error is the
+// angle between the estimated and true up directions in the body frame.
+func replay(f AttitudeFilter, samples []sensorSample, dt float64, useMag bool) (tilt, total float64) {
+	for _, s := range samples {
+		mag := s.mag
+		if !useMag {
+			mag = Vec3{}
+		}
+		f.Update(s.gyro, s.accel, mag, dt)
+	}
+	last := samples[len(samples)-1]
+	q := f.Attitude()
+	up := new(Hamilton).Conj(q).Rotate(Vec3{0, 0, 1})
+	trueUp := new(Hamilton).Conj(last.truth).Rotate(Vec3{0, 0, 1})
+	tilt = math.Acos(math.Min(1, up.Dot(trueUp)))
+	return tilt, angleBetweenRotations(q, last.truth)
+}
+
+func TestAttitudeFilters(t *testing.T) {
+	const dt = 0.01
+	samples := replayTrajectory(3000, dt, Vec3{})
+	var tests = []struct {
+		name string
+		f    func() AttitudeFilter
+	}{
+		{"Madgwick", func() AttitudeFilter { return NewMadgwickFilter(0.1) }},
+		{"Mahony", func() AttitudeFilter { return NewMahonyFilter(1, 0) }},
+		{"complementary", func() AttitudeFilter { return NewComplementaryFilter(0.02) }},
+	}
+	for _, tt := range tests {
+		if tilt, _ := replay(tt.f(), samples, dt, false); tilt > 0.01 {
+			t.Errorf("%s filter without magnetometer: tilt error %v", tt.name, tilt)
+		}
+		if _, total := replay(tt.f(), samples, dt, true); total > 0.01 {
+			t.Errorf("%s filter with magnetometer: error %v", tt.name, total)
+		}
+	}
+}
+
+func TestMahonyFilterBias(t *testing.T) {
+	const dt = 0.01
+	bias := Vec3{0.02, -0.01, 0.015}
+	samples := replayTrajectory(6000, dt, bias)
+	f := NewMahonyFilter(1, 0.3)
+	if _, total := replay(f, samples, dt, true); total > 0.01 {
+		t.Errorf("error with gyroscope bias = %v", total)
+	}
+	if d := f.Integral.Add(bias).Norm(); d > 0.002 {
+		t.Errorf("integral term %v does not cancel the bias %v", f.Integral, bias)
+	}
+}
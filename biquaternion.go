@@ -0,0 +1,311 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strings"
+)
+
+var symbBiquaternion = [4]string{"", "i", "j", "k"}
+
+// A Biquaternion represents a biquaternion (i.e. a Hamilton quaternion with
+// complex coefficients) as an ordered array of four complex128 values, the
+// coefficients of 1, i, j, and k. The imaginary unit h of the coefficients
+// commutes with i, j, and k.
+//
+// A four-vector with time t and position x is represented by the biquaternion
+// t + h x, whose quadrance is the Minkowski quadrance t² - |x|². An
+// electromagnetic field with electric part E and magnetic part B is
+// represented by the biquaternion E + h B (in units with c = 1).
+type Biquaternion [4]complex128
+
+// String returns the string representation of a Biquaternion value. Each
+// coefficient a + bh is written as "(a+bh)", so that the string of 1 + 2hi is
+// "((1+0h)+(0+2h)i+(0+0h)j+(0+0h)k)".
+func (z *Biquaternion) String() string {
+	a := make([]string, 9)
+	a[0] = "("
+	for i, v := range z {
+		s := fmt.Sprintf("%g", real(v))
+		switch {
+		case math.Signbit(imag(v)):
+			s += fmt.Sprintf("%gh", imag(v))
+		case math.IsInf(imag(v), +1):
+			s += "+Infh"
+		default:
+			s += fmt.Sprintf("+%gh", imag(v))
+		}
+		if i > 0 {
+			a[2*i] = "+"
+		}
+		a[2*i+1] = "(" + s + ")" + symbBiquaternion[i]
+	}
+	a[8] = ")"
+	return strings.Join(a, "")
+}
+
+// Equals returns true if y and z are equal.
+func (z *Biquaternion) Equals(y *Biquaternion) bool {
+	for i, v := range y {
+		if notEquals(real(v), real(z[i])) || notEquals(imag(v), imag(z[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy copies y onto z, and returns z.
+func (z *Biquaternion) Copy(y *Biquaternion) *Biquaternion {
+	for i, v := range y {
+		z[i] = v
+	}
+	return z
+}
+
+// NewBiquaternion returns a pointer to a Biquaternion value made from four
+// given complex128 values.
+func NewBiquaternion(a, b, c, d complex128) *Biquaternion {
+	z := new(Biquaternion)
+	z[0] = a
+	z[1] = b
+	z[2] = c
+	z[3] = d
+	return z
+}
+
+// Scal sets z equal to y scaled by a (with a being a complex128), and returns
+// z.
+func (z *Biquaternion) Scal(y *Biquaternion, a complex128) *Biquaternion {
+	for i, v := range y {
+		z[i] = a * v
+	}
+	return z
+}
+
+// Dil sets z equal to the dilation of y by a, and returns z.
+func (z *Biquaternion) Dil(y *Biquaternion, a float64) *Biquaternion {
+	return z.Scal(y, complex(a, 0))
+}
+
+// Neg sets z equal to the negative of y, and returns z.
+func (z *Biquaternion) Neg(y *Biquaternion) *Biquaternion {
+	return z.Dil(y, -1)
+}
+
+// Conj sets z equal to the quaternion conjugate of y, which flips the signs
+// of the coefficients of i, j, and k, and returns z.
+func (z *Biquaternion) Conj(y *Biquaternion) *Biquaternion {
+	z[0] = y[0]
+	for i, v := range y[1:] {
+		z[i+1] = -v
+	}
+	return z
+}
+
+// CConj sets z equal to the complex conjugate of y, which conjugates each
+// coefficient, and returns z.
+func (z *Biquaternion) CConj(y *Biquaternion) *Biquaternion {
+	for i, v := range y {
+		z[i] = cmplx.Conj(v)
+	}
+	return z
+}
+
+// BiConj sets z equal to the biconjugate of y (i.e. both the quaternion and
+// the complex conjugate), and returns z.
+func (z *Biquaternion) BiConj(y *Biquaternion) *Biquaternion {
+	return z.Conj(z.CConj(y))
+}
+
+// Add sets z equal to the sum of x and y, and returns z.
+func (z *Biquaternion) Add(x, y *Biquaternion) *Biquaternion {
+	for i, v := range x {
+		z[i] = v + y[i]
+	}
+	return z
+}
+
+// Sub sets z equal to the difference of x and y, and returns z.
+func (z *Biquaternion) Sub(x, y *Biquaternion) *Biquaternion {
+	for i, v := range x {
+		z[i] = v - y[i]
+	}
+	return z
+}
+
+// Mul sets z equal to the product of x and y, and returns z.
+//
+// The basis elements i, j, and k multiply as the Hamilton basis elements, and
+// they commute with the complex coefficients.
+func (z *Biquaternion) Mul(x, y *Biquaternion) *Biquaternion {
+	p := new(Biquaternion).Copy(x)
+	q := new(Biquaternion).Copy(y)
+	z[0] = (p[0] * q[0]) - (p[1] * q[1]) - (p[2] * q[2]) - (p[3] * q[3])
+	z[1] = (p[0] * q[1]) + (p[1] * q[0]) + (p[2] * q[3]) - (p[3] * q[2])
+	z[2] = (p[0] * q[2]) - (p[1] * q[3]) + (p[2] * q[0]) + (p[3] * q[1])
+	z[3] = (p[0] * q[3]) + (p[1] * q[2]) - (p[2] * q[1]) + (p[3] * q[0])
+	return z
+}
+
+// Commutator sets z equal to the commutator of x and y, and returns z.
+func (z *Biquaternion) Commutator(x, y *Biquaternion) *Biquaternion {
+	return z.Sub(new(Biquaternion).Mul(x, y), new(Biquaternion).Mul(y, x))
+}
+
+// Quad returns the quadrance of z (i.e. the product of z and its quaternion
+// conjugate), which is a complex128 value.
+func (z *Biquaternion) Quad() complex128 {
+	return (z[0] * z[0]) + (z[1] * z[1]) + (z[2] * z[2]) + (z[3] * z[3])
+}
+
+// MinkowskiQuad returns the real part of the quadrance of z. If z is the
+// four-vector t + h x, then this is the Minkowski quadrance t² - |x|².
+func (z *Biquaternion) MinkowskiQuad() float64 {
+	return real(z.Quad())
+}
+
+// IsZeroDiv returns true if z is a zero divisor (i.e. it has zero quadrance).
+func (z *Biquaternion) IsZeroDiv() bool {
+	q := z.Quad()
+	return !notEquals(real(q), 0) && !notEquals(imag(q), 0)
+}
+
+// Inv sets z equal to the inverse of y, and returns z. If y is a zero
+// divisor, then Inv panics.
+func (z *Biquaternion) Inv(y *Biquaternion) *Biquaternion {
+	if y.IsZeroDiv() {
+		panic("inverse of zero divisor")
+	}
+	return z.Scal(new(Biquaternion).Conj(y), 1/y.Quad())
+}
+
+// Quo sets z equal to the quotient of x and y, and returns z. If y is a zero
+// divisor, then Quo panics.
+func (z *Biquaternion) Quo(x, y *Biquaternion) *Biquaternion {
+	if y.IsZeroDiv() {
+		panic("denominator is zero divisor")
+	}
+	return z.Scal(new(Biquaternion).Mul(x, new(Biquaternion).Conj(y)), 1/y.Quad())
+}
+
+// FourVectorBiquaternion returns the biquaternion t + h x of the four-vector
+// with time t and position x.
+func FourVectorBiquaternion(t float64, x Vec3) *Biquaternion {
+	return NewBiquaternion(complex(t, 0), complex(0, x[0]), complex(0, x[1]), complex(0, x[2]))
+}
+
+// FourVector returns the time and position of the four-vector t + h x
+// closest to z.
+func (z *Biquaternion) FourVector() (t float64, x Vec3) {
+	return real(z[0]), Vec3{imag(z[1]), imag(z[2]), imag(z[3])}
+}
+
+// BoostBiquaternion returns the unit biquaternion of the Lorentz boost with
+// rapidity vector ξ, which accelerates a particle at rest to the velocity
+// tanh(|ξ|) ξ/|ξ|.
+func BoostBiquaternion(ξ Vec3) *Biquaternion {
+	φ := ξ.Norm()
+	if φ == 0 {
+		return NewBiquaternion(1, 0, 0, 0)
+	}
+	n := ξ.Scale(math.Sinh(φ/2) / φ)
+	return NewBiquaternion(
+		complex(math.Cosh(φ/2), 0),
+		complex(0, n[0]), complex(0, n[1]), complex(0, n[2]),
+	)
+}
+
+// FromHamilton sets z equal to the biquaternion with real coefficients equal
+// to the components of x, and returns z. If x is a unit, then z is the
+// Lorentz transformation that rotates space as x.Rotate does.
+func (z *Biquaternion) FromHamilton(x *Hamilton) *Biquaternion {
+	a, b, c, d := x.Cartesian()
+	z[0], z[1], z[2], z[3] = complex(a, 0), complex(b, 0), complex(c, 0), complex(d, 0)
+	return z
+}
+
+// Hamilton returns the Hamilton value made from the real parts of the
+// coefficients of z, and true if the imaginary parts vanish.
+func (z *Biquaternion) Hamilton() (*Hamilton, bool) {
+	x := NewHamilton(real(z[0]), real(z[1]), real(z[2]), real(z[3]))
+	for _, v := range z {
+		if notEquals(imag(v), 0) {
+			return x, false
+		}
+	}
+	return x, true
+}
+
+// Lorentz sets z equal to the Lorentz transformation l x BiConj(l) of x, and
+// returns z. If l is a unit biquaternion and x is a four-vector, then z is a
+// four-vector with the same Minkowski quadrance.
+func (z *Biquaternion) Lorentz(l, x *Biquaternion) *Biquaternion {
+	p := new(Biquaternion).Mul(l, x)
+	return z.Mul(p, new(Biquaternion).BiConj(l))
+}
+
+// FieldBiquaternion returns the biquaternion E + h B of the electromagnetic
+// field with electric part e and magnetic part b.
+func FieldBiquaternion(e, b Vec3) *Biquaternion {
+	return NewBiquaternion(0, complex(e[0], b[0]), complex(e[1], b[1]), complex(e[2], b[2]))
+}
+
+// Field returns the electric and magnetic parts of the field E + h B closest
+// to z.
+func (z *Biquaternion) Field() (e, b Vec3) {
+	return Vec3{real(z[1]), real(z[2]), real(z[3])}, Vec3{imag(z[1]), imag(z[2]), imag(z[3])}
+}
+
+// FieldTransform sets z equal to the electromagnetic field f seen after the
+// Lorentz transformation l (i.e. l f Inv(l)), and returns z. The square of a
+// field is the complex scalar (|B|² - |E|²) - 2h Dot(E, B), which is
+// invariant. If l is a zero divisor, then FieldTransform panics.
+func (z *Biquaternion) FieldTransform(l, f *Biquaternion) *Biquaternion {
+	p := new(Biquaternion).Mul(l, f)
+	return z.Mul(p, new(Biquaternion).Inv(l))
+}
+
+// FromMacfarlane sets z equal to the four-vector a + h(bi + cj + dk) of the
+// Macfarlane value x = a + bs + ct + du, and returns z. This identifies the
+// quadrance of x with the Minkowski quadrance of z.
+func (z *Biquaternion) FromMacfarlane(x *Macfarlane) *Biquaternion {
+	z[0] = complex(x[0], 0)
+	z[1] = complex(0, x[1])
+	z[2] = complex(0, x[2])
+	z[3] = complex(0, x[3])
+	return z
+}
+
+// Macfarlane returns the Macfarlane value of the four-vector closest to z,
+// and true if z is a four-vector.
+func (z *Biquaternion) Macfarlane() (*Macfarlane, bool) {
+	x := NewMacfarlane(real(z[0]), imag(z[1]), imag(z[2]), imag(z[3]))
+	ok := !notEquals(imag(z[0]), 0)
+	for _, v := range z[1:] {
+		ok = ok && !notEquals(real(v), 0)
+	}
+	return x, ok
+}
+
+// FromCockle sets z equal to the image of the Cockle value x = a + bi + ct + du
+// under the algebra embedding i ↦ i, t ↦ hj, u ↦ hk, and returns z.
+func (z *Biquaternion) FromCockle(x *Cockle) *Biquaternion {
+	z[0] = complex(real(x[0]), 0)
+	z[1] = complex(imag(x[0]), 0)
+	z[2] = complex(0, real(x[1]))
+	z[3] = complex(0, imag(x[1]))
+	return z
+}
+
+// Cockle returns the Cockle value closest to z under the embedding of
+// FromCockle, and true if z lies in its image.
+func (z *Biquaternion) Cockle() (*Cockle, bool) {
+	x := NewCockle(real(z[0]), real(z[1]), imag(z[2]), imag(z[3]))
+	ok := !notEquals(imag(z[0]), 0) && !notEquals(imag(z[1]), 0) &&
+		!notEquals(real(z[2]), 0) && !notEquals(real(z[3]), 0)
+	return x, ok
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func ExampleNewBiquaternion() {
+	fmt.Println(NewBiquaternion(1, 2i, 0, 0))
+	fmt.Println(NewBiquaternion(1+2i, -3-4i, 5, -6i))
+	// Output:
+	// ((1+0h)+(0+2h)i+(0+0h)j+(0+0h)k)
+	// ((1+2h)+(-3-4h)i+(5+0h)j+(0-6h)k)
+}
+
+func TestBiquaternionConj(t *testing.T) {
+	z := NewBiquaternion(1+2i, 3+4i, 5+6i, 7+8i)
+	var tests = []struct {
+		name      string
+		got, want *Biquaternion
+	}{
+		{"Conj", new(Biquaternion).Conj(z), NewBiquaternion(1+2i, -3-4i, -5-6i, -7-8i)},
+		{"CConj", new(Biquaternion).CConj(z), NewBiquaternion(1-2i, 3-4i, 5-6i, 7-8i)},
+		{"BiConj", new(Biquaternion).BiConj(z), NewBiquaternion(1-2i, -3+4i, -5+6i, -7+8i)},
+	}
+	for _, tt := range tests {
+		if !tt.got.Equals(tt.want) {
+			t.Errorf("%s(%v) = %v, want %v", tt.name, z, tt.got, tt.want)
+		}
+	}
+}
+
+func TestBiquaternionInv(t *testing.T) {
+	z := NewBiquaternion(1+2i, 3-1i, 0.5, -2i)
+	got := new(Biquaternion).Mul(z, new(Biquaternion).Inv(z))
+	if !got.Equals(NewBiquaternion(1, 0, 0, 0)) {
+		t.Errorf("Mul(z, Inv(z)) = %v, want 1", got)
+	}
+}
+
+func TestBiquaternionCockle(t *testing.T) {
+	x, y := NewCockle(1, 2, 3, 4), NewCockle(-1, 0.5, 2, -3)
+	bx, by := new(Biquaternion).FromCockle(x), new(Biquaternion).FromCockle(y)
+	got, ok := new(Biquaternion).Mul(bx, by).Cockle()
+	if want := new(Cockle).Mul(x, y); !ok || !got.Equals(want) {
+		t.Errorf("Mul of embedded Cockle values = %v, %v, want %v", got, ok, want)
+	}
+	if q := bx.Quad(); notEquals(real(q), x.Quad()) || notEquals(imag(q), 0) {
+		t.Errorf("Quad(%v) = %v, want %v", bx, q, x.Quad())
+	}
+	if _, ok := NewBiquaternion(1, 1i, 0, 0).Cockle(); ok {
+		t.Error("1 + hi is reported as a Cockle value")
+	}
+}
+
+func TestBiquaternionMacfarlane(t *testing.T) {
+	x := NewMacfarlane(3, 1, -2, 0.5)
+	z := new(Biquaternion).FromMacfarlane(x)
+	if notEquals(z.MinkowskiQuad(), x.Quad()) {
+		t.Errorf("MinkowskiQuad(%v) = %v, want %v", z, z.MinkowskiQuad(), x.Quad())
+	}
+	if y, ok := z.Macfarlane(); !ok || !y.Equals(x) {
+		t.Errorf("Macfarlane(%v) = %v, %v, want %v", z, y, ok, x)
+	}
+}
+
+func TestBiquaternionBoost(t *testing.T) {
+	ξ := Vec3{0.3, -0.4, 1.2}
+	l := BoostBiquaternion(ξ)
+	if cmplx.Abs(l.Quad()-1) > 1e-12 {
+		t.Errorf("Quad(%v) = %v, want 1", l, l.Quad())
+	}
+	// A particle at rest acquires the velocity tanh(|ξ|) along ξ.
+	τ, x := new(Biquaternion).Lorentz(l, FourVectorBiquaternion(1, Vec3{})).FourVector()
+	v := x.Scale(1 / τ)
+	want := ξ.Unit().Scale(math.Tanh(ξ.Norm()))
+	if !closeVec3(v, want, 1e-12) || notEquals(τ, math.Cosh(ξ.Norm())) {
+		t.Errorf("boosted velocity = %v, want %v", v, want)
+	}
+	// The Minkowski quadrance is invariant under boosts and rotations.
+	r := new(Biquaternion).FromHamilton(RotationHamilton(Vec3{1, 1, 0}, 2))
+	l.Mul(l, r)
+	p := FourVectorBiquaternion(2, Vec3{0.5, -1, 0.25})
+	q := new(Biquaternion).Lorentz(l, p)
+	if notEquals(q.MinkowskiQuad(), p.MinkowskiQuad()) {
+		t.Errorf("MinkowskiQuad changed from %v to %v", p.MinkowskiQuad(), q.MinkowskiQuad())
+	}
+	if _, ok := q.Macfarlane(); !ok {
+		t.Errorf("Lorentz(%v, %v) = %v is not a four-vector", l, p, q)
+	}
+}
+
+func TestBiquaternionFieldTransform(t *testing.T) {
+	f := FieldBiquaternion(Vec3{1, 2, 0}, Vec3{0, -1, 3})
+	l := BoostBiquaternion(Vec3{0.5, 0, -0.7})
+	g := new(Biquaternion).FieldTransform(l, f)
+	if !g.Mul(g, g).Equals(new(Biquaternion).Mul(f, f)) {
+		t.Errorf("field invariants changed under %v", l)
+	}
+	// A field parallel to the boost is unchanged.
+	f = FieldBiquaternion(Vec3{0, 0, 2}, Vec3{0, 0, 1})
+	l = BoostBiquaternion(Vec3{0, 0, 0.9})
+	if g.FieldTransform(l, f); !g.Equals(f) {
+		t.Errorf("FieldTransform(%v, %v) = %v, want %v", l, f, g, f)
+	}
+	e, b := g.Field()
+	if e != (Vec3{0, 0, 2}) || b != (Vec3{0, 0, 1}) {
+		t.Errorf("Field(%v) = %v, %v", g, e, b)
+	}
+}
@@ -224,7 +224,7 @@ func (z *Cockle) IsNilpotent(n int) bool {
 	if z.Equals(zeroK) {
 		return true
 	}
-	p := oneK
+	p := new(Cockle).Copy(oneK)
 	for i := 0; i < n; i++ {
 		p.Mul(p, z)
 		if p.Equals(zeroK) {
@@ -54,11 +54,43 @@ func TestCockleInv(t *testing.T) {}
 
 func TestIsCockleInf(t *testing.T) {}
 
-func TestCockleIsIndempotent(t *testing.T) {}
+func TestCockleIsIndempotent(t *testing.T) {
+	var tests = []struct {
+		z    *Cockle
+		want bool
+	}{
+		{NewCockle(0, 0, 0, 0), true},
+		{NewCockle(1, 0, 0, 0), true},
+		{IdempotentCockle(0, 0), true},
+		{IdempotentCockle(0.8, -2.1), true},
+		{NewCockle(0.5, 0.5, 0, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.z.IsIndempotent(); got != tt.want {
+			t.Errorf("IsIndempotent(%v) = %v, want %v", tt.z, got, tt.want)
+		}
+	}
+}
 
 func TestIsCockleNaN(t *testing.T) {}
 
-func TestCockleIsNilpotent(t *testing.T) {}
+func TestCockleIsNilpotent(t *testing.T) {
+	var tests = []struct {
+		z    *Cockle
+		want bool
+	}{
+		{NewCockle(0, 0, 0, 0), true},
+		{NilpotentCockle(2, 0.3), true},
+		{NilpotentCockle(-0.5, 4), true},
+		{NewCockle(0, 1, 1, 1), false},
+		{NewCockle(1, 0, 0, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.z.IsNilpotent(4); got != tt.want {
+			t.Errorf("IsNilpotent(%v) = %v, want %v", tt.z, got, tt.want)
+		}
+	}
+}
 
 func TestCockleIsZeroDiv(t *testing.T) {}
 
@@ -0,0 +1,244 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// The functions in this file represent a color image as a grid of pure
+// Hamilton values, indexed as grid[y][x], where the pixel with red, green, and
+// blue intensities r, g, and b in [0, 1] is the value ri + gj + bk. The gray
+// colors lie along the gray axis (i + j + k)/√3.
+
+// grayAxis returns the unit vector of the gray axis.
+func grayAxis() Vec3 {
+	return Vec3{1, 1, 1}.Unit()
+}
+
+// ImageToHamilton returns the grid of pure Hamilton values of the colors of
+// img. Alpha is ignored.
+func ImageToHamilton(img image.Image) [][]Hamilton {
+	b := img.Bounds()
+	g := make([][]Hamilton, b.Dy())
+	for y := range g {
+		g[y] = make([]Hamilton, b.Dx())
+		for x := range g[y] {
+			c := color.NRGBA64Model.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA64)
+			g[y][x] = *NewHamilton(0, float64(c.R)/0xffff, float64(c.G)/0xffff, float64(c.B)/0xffff)
+		}
+	}
+	return g
+}
+
+// HamiltonToImage returns the opaque image of the grid g. The real part of
+// each value is ignored, and the intensities are clamped to [0, 1].
+func HamiltonToImage(g [][]Hamilton) *image.NRGBA64 {
+	h := len(g)
+	w := 0
+	if h > 0 {
+		w = len(g[0])
+	}
+	img := image.NewNRGBA64(image.Rect(0, 0, w, h))
+	clamp := func(v float64) uint16 {
+		return uint16(math.Round(math.Max(0, math.Min(1, v)) * 0xffff))
+	}
+	for y := range g {
+		for x := range g[y] {
+			v := g[y][x].Vec()
+			img.SetNRGBA64(x, y, color.NRGBA64{R: clamp(v[0]), G: clamp(v[1]), B: clamp(v[2]), A: 0xffff})
+		}
+	}
+	return img
+}
+
+// WriteHamiltonPNG writes the image of the grid g to w in PNG format.
+func WriteHamiltonPNG(w io.Writer, g [][]Hamilton) error {
+	return png.Encode(w, HamiltonToImage(g))
+}
+
+// Convolve returns the quaternion convolution of the grid f with the left
+// kernel l and the right kernel r, which is the sum of l[s][t]*p*r[s][t] over
+// the kernel, with p the pixel of f at offset (s - cy, t - cx) behind the
+// current pixel and (cy, cx) the center of the kernel. Pixels outside f are
+// replaced with the nearest edge pixel. If the kernels have different sizes,
+// then Convolve panics.
+func Convolve(f, l, r [][]Hamilton) [][]Hamilton {
+	if len(l) != len(r) || len(l) > 0 && len(l[0]) != len(r[0]) {
+		panic("kernels have different sizes")
+	}
+	h := len(f)
+	if h == 0 || len(l) == 0 {
+		return nil
+	}
+	w := len(f[0])
+	cy, cx := len(l)/2, len(l[0])/2
+	clamp := func(i, n int) int {
+		if i < 0 {
+			return 0
+		}
+		if i >= n {
+			return n - 1
+		}
+		return i
+	}
+	g := make([][]Hamilton, h)
+	for y := range g {
+		g[y] = make([]Hamilton, w)
+		for x := range g[y] {
+			for s := range l {
+				for t := range l[s] {
+					p := &f[clamp(y-s+cy, h)][clamp(x-t+cx, w)]
+					q := new(Hamilton).Mul(&l[s][t], p)
+					g[y][x].Add(&g[y][x], q.Mul(q, &r[s][t]))
+				}
+			}
+		}
+	}
+	return g
+}
+
+// SangwineEdges returns the result of Sangwine's color edge detector on f. The
+// left and right masks
+//
+//	[ R R R ]   [ R* R* R* ]
+//	[ 0 0 0 ]   [ 0  0  0  ]
+//	[ R* R* R*] [ R  R  R  ]
+//
+// with R = exp(μπ/4) about the gray axis μ, rotate the colors above and below
+// each pixel by +π/2 and -π/2 about the gray axis. In regions of constant
+// color the rotations cancel and leave a gray pixel, while edges leave a
+// colored pixel. The results of the masks and of their transposes, which
+// detect horizontal and vertical edges, are averaged, and scaled so that a
+// region of constant color maps to its gray component. The distance of each
+// result from the gray axis, given by Chroma, measures the edge strength.
+func SangwineEdges(f [][]Hamilton) [][]Hamilton {
+	μ := grayAxis().Scale(math.Sin(math.Pi / 4))
+	R := NewHamilton(math.Cos(math.Pi/4), μ[0], μ[1], μ[2])
+	C := new(Hamilton).Conj(R)
+	var zero Hamilton
+	l := [][]Hamilton{{*R, *R, *R}, {zero, zero, zero}, {*C, *C, *C}}
+	r := [][]Hamilton{{*C, *C, *C}, {zero, zero, zero}, {*R, *R, *R}}
+	lt, rt := transposeGrid(l), transposeGrid(r)
+	a, b := Convolve(f, l, r), Convolve(f, lt, rt)
+	for y := range a {
+		for x := range a[y] {
+			a[y][x].Add(&a[y][x], &b[y][x])
+			a[y][x].Dil(&a[y][x], 1.0/12)
+		}
+	}
+	return a
+}
+
+// transposeGrid returns the transpose of the grid g.
+func transposeGrid(g [][]Hamilton) [][]Hamilton {
+	t := make([][]Hamilton, len(g[0]))
+	for i := range t {
+		t[i] = make([]Hamilton, len(g))
+		for j := range g {
+			t[i][j] = g[j][i]
+		}
+	}
+	return t
+}
+
+// Chroma returns the distance of the color of p from the gray axis.
+func Chroma(p *Hamilton) float64 {
+	v := p.Vec()
+	μ := grayAxis()
+	return v.Sub(μ.Scale(v.Dot(μ))).Norm()
+}
+
+// RotateHue returns the grid f with every color rotated by the angle θ about
+// the gray axis, which changes the hue while preserving the luminance and the
+// saturation.
+func RotateHue(f [][]Hamilton, θ float64) [][]Hamilton {
+	q := RotationHamilton(grayAxis(), θ)
+	g := make([][]Hamilton, len(f))
+	for y := range f {
+		g[y] = make([]Hamilton, len(f[y]))
+		for x := range f[y] {
+			v := q.Rotate(f[y][x].Vec())
+			a, _, _, _ := f[y][x].Cartesian()
+			g[y][x] = *NewHamilton(a, v[0], v[1], v[2])
+		}
+	}
+	return g
+}
+
+// ColorCorrelation returns the quaternion cross-correlation of the grid f
+// with the template t: for each offset (y, x) at which t fits inside f, the
+// sum of p*Conj(q) over the pixels p of f under the pixels q of t. For pure
+// values, the real part of p*Conj(q) is the dot product of the colors, and the
+// vector part is the negative of their cross product, which vanishes when the
+// colors are parallel. If t does not fit inside f, then ColorCorrelation
+// returns nil. If t is empty, then ColorCorrelation panics.
+func ColorCorrelation(f, t [][]Hamilton) [][]Hamilton {
+	if len(t) == 0 || len(t[0]) == 0 {
+		panic("template is empty")
+	}
+	h, w := len(f)-len(t)+1, 0
+	if h > 0 {
+		w = len(f[0]) - len(t[0]) + 1
+	}
+	if h <= 0 || w <= 0 {
+		return nil
+	}
+	c := make([][]Hamilton, h)
+	for y := range c {
+		c[y] = make([]Hamilton, w)
+		for x := range c[y] {
+			for s := range t {
+				for u := range t[s] {
+					p := new(Hamilton).Conj(&t[s][u])
+					c[y][x].Add(&c[y][x], p.Mul(&f[y+s][x+u], p))
+				}
+			}
+		}
+	}
+	return c
+}
+
+// MatchTemplate returns the offset (y, x) at which the template t best
+// matches the grid f, along with the normalized score of the match. The score
+// is the real part of the ColorCorrelation divided by the lengths of t and of
+// the window of f, so that it equals 1 exactly when the window is a positive
+// multiple of t. A window of f or a template with zero length has score 0, so
+// that if every window has zero length, then MatchTemplate returns (0, 0, 0).
+// If t is empty or does not fit inside f, then MatchTemplate panics.
+func MatchTemplate(f, t [][]Hamilton) (y, x int, score float64) {
+	c := ColorCorrelation(f, t)
+	if c == nil {
+		panic("template does not fit inside image")
+	}
+	var tq float64
+	for s := range t {
+		for u := range t[s] {
+			tq += t[s][u].Quad()
+		}
+	}
+	score = math.Inf(-1)
+	for i := range c {
+		for j := range c[i] {
+			var fq float64
+			for s := range t {
+				for u := range t[s] {
+					fq += f[i+s][j+u].Quad()
+				}
+			}
+			var v float64
+			if d := math.Sqrt(fq * tq); d > 0 {
+				v = real(c[i][j][0]) / d
+			}
+			if v > score {
+				y, x, score = i, j, v
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"testing"
+)
+
+// splitImage returns a w×h image whose left half is red and right half is
+// blue.
+func splitImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestImageToHamilton(t *testing.T) {
+	img := splitImage(6, 4)
+	g := ImageToHamilton(img)
+	if len(g) != 4 || len(g[0]) != 6 {
+		t.Fatalf("grid is %d×%d, want 4×6", len(g), len(g[0]))
+	}
+	if !g[0][0].Equals(NewHamilton(0, 1, 0, 0)) || !g[3][5].Equals(NewHamilton(0, 0, 0, 1)) {
+		t.Errorf("pixels = %v, %v, want i, k", &g[0][0], &g[3][5])
+	}
+	var buf bytes.Buffer
+	if err := WriteHamiltonPNG(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	back, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := ImageToHamilton(back); !closeGrid(h, g) {
+		t.Errorf("PNG round trip = %v, want %v", h, g)
+	}
+}
+
+func TestConvolve(t *testing.T) {
+	f := ImageToHamilton(splitImage(5, 5))
+	var zero Hamilton
+	one := *NewHamilton(1, 0, 0, 0)
+	id := [][]Hamilton{{zero, zero, zero}, {zero, one, zero}, {zero, zero, zero}}
+	if g := Convolve(f, id, id); !closeGrid(g, f) {
+		t.Errorf("Convolve with the identity kernel = %v, want %v", g, f)
+	}
+	// A single off-center entry shifts the image.
+	shift := [][]Hamilton{{zero, zero, zero}, {zero, zero, one}, {zero, zero, zero}}
+	g := Convolve(f, shift, shift)
+	if !g[2][3].Equals(&f[2][2]) {
+		t.Errorf("shifted pixel = %v, want %v", &g[2][3], &f[2][2])
+	}
+}
+
+func TestSangwineEdges(t *testing.T) {
+	f := ImageToHamilton(splitImage(8, 6))
+	g := SangwineEdges(f)
+	if c := Chroma(&g[3][1]); notEquals(c, 0) {
+		t.Errorf("chroma inside a constant region = %v, want 0", c)
+	}
+	want := NewHamilton(0, 1.0/3, 1.0/3, 1.0/3)
+	if !g[3][1].ApproxEquals(want) {
+		t.Errorf("constant red region maps to %v, want its gray component %v", &g[3][1], want)
+	}
+	if c := Chroma(&g[3][4]); c < 0.1 {
+		t.Errorf("chroma at the edge = %v, want a colored pixel", c)
+	}
+}
+
+func TestRotateHue(t *testing.T) {
+	f := [][]Hamilton{{*NewHamilton(0, 1, 0, 0), *NewHamilton(0, 0.5, 0.5, 0.5)}}
+	g := RotateHue(f, 2*math.Pi/3)
+	if !g[0][0].ApproxEquals(NewHamilton(0, 0, 1, 0)) {
+		t.Errorf("red rotated by 2π/3 = %v, want green", &g[0][0])
+	}
+	if !g[0][1].ApproxEquals(&f[0][1]) {
+		t.Errorf("gray rotated = %v, want %v", &g[0][1], &f[0][1])
+	}
+}
+
+func TestMatchTemplate(t *testing.T) {
+	f := make([][]Hamilton, 7)
+	for y := range f {
+		f[y] = make([]Hamilton, 9)
+		for x := range f[y] {
+			f[y][x] = *NewHamilton(0, 0.5, 0.5, 0.5)
+		}
+	}
+	tmpl := [][]Hamilton{
+		{*NewHamilton(0, 1, 0, 0), *NewHamilton(0, 0, 1, 0)},
+		{*NewHamilton(0, 0, 0, 1), *NewHamilton(0, 1, 1, 0)},
+	}
+	for s := range tmpl {
+		for u := range tmpl[s] {
+			f[4+s][2+u] = tmpl[s][u]
+		}
+	}
+	y, x, score := MatchTemplate(f, tmpl)
+	if y != 4 || x != 2 || notEquals(score, 1) {
+		t.Errorf("MatchTemplate = (%d, %d, %v), want (4, 2, 1)", y, x, score)
+	}
+	c := ColorCorrelation(f, tmpl)
+	if v := c[4][2].Vec(); notEquals(v.Norm(), 0) {
+		t.Errorf("vector part of the correlation at the match = %v, want 0", v)
+	}
+	// For pure values, the vector part is the negative of the cross product.
+	red, green := [][]Hamilton{{*NewHamilton(0, 1, 0, 0)}}, [][]Hamilton{{*NewHamilton(0, 0, 1, 0)}}
+	if v := ColorCorrelation(red, green)[0][0].Vec(); notEquals(v[2], -1) {
+		t.Errorf("correlation of red with green = %v, want -k", v)
+	}
+	// A black image matches nothing.
+	black := [][]Hamilton{make([]Hamilton, 3), make([]Hamilton, 3)}
+	if y, x, score := MatchTemplate(black, tmpl); y != 0 || x != 0 || score != 0 {
+		t.Errorf("MatchTemplate of a black image = (%d, %d, %v), want (0, 0, 0)", y, x, score)
+	}
+}
+
+func TestMatchTemplateEmpty(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MatchTemplate did not panic on an empty template")
+		}
+	}()
+	MatchTemplate([][]Hamilton{make([]Hamilton, 2)}, [][]Hamilton{{}})
+}
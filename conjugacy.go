@@ -0,0 +1,200 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Every Hamilton, Cockle, and Macfarlane value x = a + v satisfies the
+// quadratic x² - Trace(x)x + Norm(x) = 0, with Trace(x) = 2a and Norm(x) equal
+// to the quadrance of x. For the associative Hamilton and Cockle values, two
+// values are similar (i.e. conjugate) if and only if they have the same
+// minimal polynomial.
+
+// Trace returns the trace 2a of z = a + bi + cj + dk.
+func (z *Hamilton) Trace() float64 {
+	return 2 * real(z[0])
+}
+
+// Norm returns the norm of z, which equals its quadrance.
+func (z *Hamilton) Norm() float64 {
+	return z.Quad()
+}
+
+// MinimalPolynomial returns the coefficients, in ascending order, of the
+// monic polynomial of least degree that has z as a root. It is x - a for a
+// real z = a, and x² - Trace(z)x + Norm(z) otherwise.
+func (z *Hamilton) MinimalPolynomial() []float64 {
+	if !notEquals(z.Vec().Norm(), 0) {
+		return []float64{-real(z[0]), 1}
+	}
+	return []float64{z.Norm(), -z.Trace(), 1}
+}
+
+// Eigenvalues returns the standard eigenvalues a ± |v|i of z = a + v, which
+// are the eigenvalues of its 2×2 complex matrix.
+func (z *Hamilton) Eigenvalues() (complex128, complex128) {
+	a, r := real(z[0]), z.Vec().Norm()
+	return complex(a, r), complex(a, -r)
+}
+
+// IsSimilar returns true if there is a non-zero q with z = q*y*Inv(q).
+func (z *Hamilton) IsSimilar(y *Hamilton) bool {
+	return equalPolys(z.MinimalPolynomial(), y.MinimalPolynomial())
+}
+
+// CanonicalForm sets z equal to the representative a + |v|i of the similarity
+// class of y = a + v, and returns z.
+func (z *Hamilton) CanonicalForm(y *Hamilton) *Hamilton {
+	return z.Copy(NewHamilton(real(y[0]), y.Vec().Norm(), 0, 0))
+}
+
+// SimilarityTransform sets z equal to a unit q with x = q*y*Inv(q), and
+// returns z. The rotation q takes the vector part of y to that of x. If x and
+// y are not similar, then SimilarityTransform panics.
+func (z *Hamilton) SimilarityTransform(x, y *Hamilton) *Hamilton {
+	if !x.IsSimilar(y) {
+		panic("values are not similar")
+	}
+	u, w := y.Vec(), x.Vec()
+	if !notEquals(u.Norm(), 0) {
+		return z.Copy(oneH)
+	}
+	return z.Copy(rotationBetween(u.Unit(), w.Unit()))
+}
+
+// rotationBetween returns a unit Hamilton value that rotates the unit vector u
+// onto the unit vector w.
+func rotationBetween(u, w Vec3) *Hamilton {
+	c := u.Dot(w)
+	if c > -1+delta {
+		n := u.Cross(w)
+		q := NewHamilton(1+c, n[0], n[1], n[2])
+		return q.Normalize(q)
+	}
+	// u and w are opposite, so rotate by π about any axis orthogonal to u.
+	e := Vec3{1, 0, 0}
+	if math.Abs(u[0]) > 0.5 {
+		e = Vec3{0, 1, 0}
+	}
+	n := u.Cross(e).Unit()
+	return NewHamilton(0, n[0], n[1], n[2])
+}
+
+// Trace returns the trace 2a of z = a + bi + ct + du.
+func (z *Cockle) Trace() float64 {
+	return 2 * real(z[0])
+}
+
+// Norm returns the norm of z, which equals its quadrance.
+func (z *Cockle) Norm() float64 {
+	return z.Quad()
+}
+
+// Eigenvalues returns the roots of the characteristic polynomial of z, which
+// are the eigenvalues of its 2×2 real matrix. They are real if the vector part
+// of z has non-positive quadrance, and complex conjugates otherwise.
+func (z *Cockle) Eigenvalues() (complex128, complex128) {
+	a := real(z[0])
+	r := cmplx.Sqrt(complex(a*a-z.Norm(), 0))
+	return complex(a, 0) + r, complex(a, 0) - r
+}
+
+// IsSimilar returns true if there is an invertible q with z = q*y*Inv(q).
+func (z *Cockle) IsSimilar(y *Cockle) bool {
+	return equalPolys(z.MinimalPolynomial(), y.MinimalPolynomial())
+}
+
+// CanonicalForm sets z equal to the representative of the similarity class of
+// y = a + v, and returns z. The representative is a if v is zero, a + |v|i if
+// v is timelike, a + |v|t if v is spacelike, and a + i + t if v is a non-zero
+// null vector, where |v|² is the absolute value of the quadrance of v.
+func (z *Cockle) CanonicalForm(y *Cockle) *Cockle {
+	a := real(y[0])
+	if len(y.MinimalPolynomial()) == 2 {
+		return z.Copy(NewCockle(a, 0, 0, 0))
+	}
+	q := y.Norm() - a*a
+	switch {
+	case !notEquals(q, 0):
+		return z.Copy(NewCockle(a, 1, 1, 0))
+	case q > 0:
+		return z.Copy(NewCockle(a, math.Sqrt(q), 0, 0))
+	}
+	return z.Copy(NewCockle(a, 0, math.Sqrt(-q), 0))
+}
+
+// SimilarityTransform sets z equal to an invertible q with x = q*y*Inv(q), and
+// returns z. If x and y are not similar, then SimilarityTransform panics.
+func (z *Cockle) SimilarityTransform(x, y *Cockle) *Cockle {
+	if !x.IsSimilar(y) {
+		panic("values are not similar")
+	}
+	if len(x.MinimalPolynomial()) == 2 {
+		return z.Copy(oneK)
+	}
+	// Both matrices are similar to the companion matrix of their common
+	// characteristic polynomial, through the bases (e, Me) of cyclic vectors.
+	p, s := cyclicBasis(cockleMatrix(x)), cyclicBasis(cockleMatrix(y))
+	return z.Mul(cockleFromMatrix(p), new(Cockle).Inv(cockleFromMatrix(s)))
+}
+
+// cyclicBasis returns the matrix with columns e and m*e, for the vector e
+// among (1, 0), (0, 1), and (1, 1) that makes it best conditioned. The matrix
+// m must not be a multiple of the identity.
+func cyclicBasis(m [2][2]float64) [2][2]float64 {
+	var best [2][2]float64
+	var bestDet float64
+	for _, e := range [][2]float64{{1, 0}, {0, 1}, {1, 1}} {
+		f := [2]float64{m[0][0]*e[0] + m[0][1]*e[1], m[1][0]*e[0] + m[1][1]*e[1]}
+		if d := math.Abs(e[0]*f[1] - f[0]*e[1]); d > bestDet {
+			best = [2][2]float64{{e[0], f[0]}, {e[1], f[1]}}
+			bestDet = d
+		}
+	}
+	return best
+}
+
+// Trace returns the trace 2a of z = a + bs + ct + du.
+func (z *Macfarlane) Trace() float64 {
+	return 2 * z[0]
+}
+
+// Norm returns the norm of z, which equals its quadrance.
+func (z *Macfarlane) Norm() float64 {
+	return z.Quad()
+}
+
+// MinimalPolynomial returns the coefficients, in ascending order, of the
+// monic polynomial of least degree that has z as a root. It is x - a for a
+// real z = a, and x² - Trace(z)x + Norm(z) otherwise.
+func (z *Macfarlane) MinimalPolynomial() []float64 {
+	if !notEquals(math.Hypot(z[1], math.Hypot(z[2], z[3])), 0) {
+		return []float64{-z[0], 1}
+	}
+	return []float64{z.Norm(), -z.Trace(), 1}
+}
+
+// Eigenvalues returns the roots a ± |v| of the minimal polynomial of
+// z = a + v, which are always real.
+func (z *Macfarlane) Eigenvalues() (float64, float64) {
+	r := math.Hypot(z[1], math.Hypot(z[2], z[3]))
+	return z[0] + r, z[0] - r
+}
+
+// equalPolys returns true if the polynomials p and q have the same degree and
+// equal coefficients.
+func equalPolys(p, q []float64) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for n := range p {
+		if notEquals(p[n], q[n]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHamiltonMinimalPolynomial(t *testing.T) {
+	var tests = []*Hamilton{
+		NewHamilton(2, 0, 0, 0),
+		NewHamilton(1, 2, -3, 0.5),
+		NewHamilton(0, 0, 0, 1),
+	}
+	for _, z := range tests {
+		p := z.MinimalPolynomial()
+		v := new(Hamilton)
+		for n := len(p) - 1; n >= 0; n-- {
+			v.Mul(v, z)
+			v.Add(v, NewHamilton(p[n], 0, 0, 0))
+		}
+		if !v.ApproxEquals(zeroH) {
+			t.Errorf("MinimalPolynomial(%v)(%v) = %v, want 0", z, z, v)
+		}
+		λ, μ := z.Eigenvalues()
+		if notEquals(real(λ+μ), z.Trace()) || notEquals(real(λ*μ), z.Norm()) {
+			t.Errorf("Eigenvalues(%v) = %v, %v", z, λ, μ)
+		}
+	}
+}
+
+func TestHamiltonSimilarityTransform(t *testing.T) {
+	var tests = []struct {
+		x, y *Hamilton
+	}{
+		{NewHamilton(1, 2, 2, 1), NewHamilton(1, 0, -3, 0)},
+		{NewHamilton(0.5, 0, 0, 1), NewHamilton(0.5, 0, 0, -1)},
+		{NewHamilton(3, 0, 0, 0), NewHamilton(3, 0, 0, 0)},
+	}
+	for _, tt := range tests {
+		if !tt.x.IsSimilar(tt.y) {
+			t.Errorf("%v and %v are not similar", tt.x, tt.y)
+			continue
+		}
+		q := new(Hamilton).SimilarityTransform(tt.x, tt.y)
+		got := new(Hamilton).Mul(q, tt.y)
+		got.Mul(got, new(Hamilton).Inv(q))
+		if !got.ApproxEquals(tt.x) {
+			t.Errorf("q*y*Inv(q) = %v, want %v", got, tt.x)
+		}
+		c, d := new(Hamilton).CanonicalForm(tt.x), new(Hamilton).CanonicalForm(tt.y)
+		if !c.ApproxEquals(d) {
+			t.Errorf("CanonicalForm(%v) = %v, CanonicalForm(%v) = %v", tt.x, c, tt.y, d)
+		}
+	}
+	if NewHamilton(1, 2, 0, 0).IsSimilar(NewHamilton(1, 0, 3, 0)) {
+		t.Error("values with different norms are similar")
+	}
+}
+
+func TestCockleSimilarityTransform(t *testing.T) {
+	var tests = []struct {
+		x, y *Cockle
+		want *Cockle
+	}{
+		{NewCockle(1, 2, 1, 1), NewCockle(1, -3, 2, math.Sqrt(3)), NewCockle(1, math.Sqrt(2), 0, 0)},
+		{NewCockle(0, 1, 2, 0), NewCockle(0, 0, 1, math.Sqrt(2)), NewCockle(0, 0, math.Sqrt(3), 0)},
+		{NewCockle(2, 1, 0, 1), NewCockle(2, -1, 0.6, 0.8), NewCockle(2, 1, 1, 0)},
+		{NewCockle(-1, 0, 0, 0), NewCockle(-1, 0, 0, 0), NewCockle(-1, 0, 0, 0)},
+	}
+	for _, tt := range tests {
+		if !tt.x.IsSimilar(tt.y) {
+			t.Errorf("%v and %v are not similar", tt.x, tt.y)
+			continue
+		}
+		q := new(Cockle).SimilarityTransform(tt.x, tt.y)
+		got := new(Cockle).Mul(q, tt.y)
+		got.Mul(got, new(Cockle).Inv(q))
+		if !got.Equals(tt.x) {
+			t.Errorf("q*y*Inv(q) = %v, want %v", got, tt.x)
+		}
+		for _, z := range []*Cockle{tt.x, tt.y} {
+			if c := new(Cockle).CanonicalForm(z); !c.Equals(tt.want) {
+				t.Errorf("CanonicalForm(%v) = %v, want %v", z, c, tt.want)
+			}
+		}
+	}
+	if NewCockle(0, 1, 0, 0).IsSimilar(NewCockle(0, 0, 1, 0)) {
+		t.Error("timelike and spacelike values are similar")
+	}
+}
+
+func TestCockleEigenvalues(t *testing.T) {
+	z := NewCockle(1, 0, 2, 0)
+	λ, μ := z.Eigenvalues()
+	if λ != 3 || μ != -1 {
+		t.Errorf("Eigenvalues(%v) = %v, %v, want 3, -1", z, λ, μ)
+	}
+	z = NewCockle(1, 2, 0, 0)
+	λ, μ = z.Eigenvalues()
+	if λ != 1+2i || μ != 1-2i {
+		t.Errorf("Eigenvalues(%v) = %v, %v, want (1+2i), (1-2i)", z, λ, μ)
+	}
+}
+
+func TestMacfarlaneMinimalPolynomial(t *testing.T) {
+	z := NewMacfarlane(1, 2, -1, 0.5)
+	p := z.MinimalPolynomial()
+	v := new(Macfarlane).Mul(z, z)
+	v.Add(v, new(Macfarlane).Scal(z, p[1]))
+	v.Add(v, NewMacfarlane(p[0], 0, 0, 0))
+	if !v.Equals(&Macfarlane{}) {
+		t.Errorf("MinimalPolynomial(%v)(%v) = %v, want 0", z, z, v)
+	}
+	if λ, μ := z.Eigenvalues(); notEquals(λ*μ, z.Norm()) || notEquals(λ+μ, z.Trace()) {
+		t.Errorf("Eigenvalues(%v) = %v, %v", z, λ, μ)
+	}
+	if p := NewMacfarlane(4, 0, 0, 0).MinimalPolynomial(); len(p) != 2 {
+		t.Errorf("MinimalPolynomial(4) = %v, want degree 1", p)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// A PointGroup represents the proper rotations of a crystallographic point
+// group as unit Hamilton values, with one value from each pair ±q.
+//
+// An orientation is a unit Hamilton value g that rotates crystal coordinates
+// into sample coordinates, so that g and Mul(g, s) describe the same physical
+// orientation for every s in the point group.
+type PointGroup []*Hamilton
+
+// halveGroup keeps one element of each pair ±q in g, choosing the one whose
+// first non-zero component is positive.
+func halveGroup(g []*Hamilton) PointGroup {
+	var s PointGroup
+	for _, h := range g {
+		if indexHamilton(s, h) >= 0 || indexHamilton(s, new(Hamilton).Neg(h)) >= 0 {
+			continue
+		}
+		a, b, c, d := h.Cartesian()
+		for _, v := range []float64{a, b, c, d} {
+			if notEquals(v, 0) {
+				if v < 0 {
+					h = new(Hamilton).Neg(h)
+				}
+				break
+			}
+		}
+		s = append(s, h)
+	}
+	return s
+}
+
+// dihedralSymmetry returns the rotations of the dihedral group of order 2n,
+// with the n-fold axis along z and a 2-fold axis along x.
+func dihedralSymmetry(n int) PointGroup {
+	var g []*Hamilton
+	for m := 0; m < n; m++ {
+		θ := 2 * math.Pi * float64(m) / float64(n)
+		g = append(g, RotationHamilton(Vec3{0, 0, 1}, θ))
+		g = append(g, RotationHamilton(Vec3{math.Cos(θ / 2), math.Sin(θ / 2), 0}, math.Pi))
+	}
+	return halveGroup(g)
+}
+
+// CubicSymmetry returns the 24 rotations of the cubic point group 432.
+func CubicSymmetry() PointGroup {
+	return halveGroup(BinaryOctahedralGroup())
+}
+
+// HexagonalSymmetry returns the 12 rotations of the hexagonal point group
+// 622, with the c axis along z.
+func HexagonalSymmetry() PointGroup {
+	return dihedralSymmetry(6)
+}
+
+// TetragonalSymmetry returns the 8 rotations of the tetragonal point group
+// 422, with the c axis along z.
+func TetragonalSymmetry() PointGroup {
+	return dihedralSymmetry(4)
+}
+
+// TrigonalSymmetry returns the 6 rotations of the trigonal point group 32,
+// with the c axis along z.
+func TrigonalSymmetry() PointGroup {
+	return dihedralSymmetry(3)
+}
+
+// OrthorhombicSymmetry returns the 4 rotations of the orthorhombic point group
+// 222.
+func OrthorhombicSymmetry() PointGroup {
+	return dihedralSymmetry(2)
+}
+
+// MonoclinicSymmetry returns the 2 rotations of the monoclinic point group 2,
+// with the unique axis along y.
+func MonoclinicSymmetry() PointGroup {
+	return PointGroup{NewHamilton(1, 0, 0, 0), NewHamilton(0, 0, 1, 0)}
+}
+
+// TriclinicSymmetry returns the trivial point group 1.
+func TriclinicSymmetry() PointGroup {
+	return PointGroup{NewHamilton(1, 0, 0, 0)}
+}
+
+// preferred returns true if the rotation x is closer to the identity than y,
+// breaking near ties by comparing the vector parts component by component,
+// starting from k. Both x and y must have non-negative real parts.
+func preferred(x, y *Hamilton) bool {
+	a, _, _, _ := x.Cartesian()
+	b, _, _, _ := y.Cartesian()
+	if notEquals(a, b) {
+		return a > b
+	}
+	u, v := x.Vec(), y.Vec()
+	for i := 2; i >= 0; i-- {
+		if notEquals(u[i], v[i]) {
+			return u[i] > v[i]
+		}
+	}
+	return false
+}
+
+// positive returns z or its negative, whichever has non-negative real part.
+func positive(z *Hamilton) *Hamilton {
+	if a, _, _, _ := z.Cartesian(); a < 0 {
+		return z.Neg(z)
+	}
+	return z
+}
+
+// FundamentalZone returns the orientation equivalent to g under s that has
+// the smallest rotation angle, with non-negative real part.
+func (s PointGroup) FundamentalZone(g *Hamilton) *Hamilton {
+	var best *Hamilton
+	for _, t := range s {
+		h := positive(new(Hamilton).Mul(g, t))
+		if best == nil || preferred(h, best) {
+			best = h
+		}
+	}
+	return best
+}
+
+// Misorientation returns the disorientation between the orientations g1 and
+// g2, as a rotation in the crystal frame of g1. Among all equivalent
+// misorientations Mul(Mul(s1, Inv(g1)*g2), s2), including the ones obtained by
+// exchanging g1 and g2, it is the one with the smallest angle, with ties
+// broken in favor of axes with large z, y, and x components (in that order).
+// For cubic symmetry, this places the axis in the standard stereographic
+// triangle.
+func (s PointGroup) Misorientation(g1, g2 *Hamilton) *Hamilton {
+	Δ := new(Hamilton).Mul(new(Hamilton).Inv(g1), g2)
+	candidates := []*Hamilton{Δ, new(Hamilton).Inv(Δ)}
+	var best *Hamilton
+	for _, d := range candidates {
+		for _, t1 := range s {
+			h := new(Hamilton).Mul(t1, d)
+			for _, t2 := range s {
+				m := positive(new(Hamilton).Mul(h, t2))
+				if best == nil || preferred(m, best) {
+					best = m
+				}
+			}
+		}
+	}
+	return best
+}
+
+// Disorientation returns the unit axis and the angle (in radians) of the
+// disorientation between g1 and g2, in the same order as AxisAngle. If the
+// angle vanishes, then the axis is zero.
+func (s PointGroup) Disorientation(g1, g2 *Hamilton) (axis Vec3, θ float64) {
+	return s.Misorientation(g1, g2).AxisAngle()
+}
+
+// RodriguesFrank returns the Rodrigues-Frank vector of the rotation z, which
+// points along the rotation axis with length tan(θ/2). If z is a half-turn,
+// then its real part is zero and the non-zero components are infinite.
+func (z *Hamilton) RodriguesFrank() Vec3 {
+	a, _, _, _ := z.Cartesian()
+	return z.Vec().Scale(1 / a)
+}
+
+// Mean returns the average of a set of orientations that are equivalent
+// under s, reduced to the fundamental zone. Each orientation is first
+// replaced by its symmetric equivalent closest to the current estimate, and
+// the estimate is updated with the eigenvector of the largest eigenvalue of
+// the sum of their outer products. If g is empty, then Mean panics.
+func (s PointGroup) Mean(g []*Hamilton) *Hamilton {
+	if len(g) == 0 {
+		panic("mean of no orientations")
+	}
+	mean := s.FundamentalZone(g[0])
+	for iter := 0; iter < 3; iter++ {
+		m := make([][]float64, 4)
+		for i := range m {
+			m[i] = make([]float64, 4)
+		}
+		inv := new(Hamilton).Inv(mean)
+		for _, h := range g {
+			d := s.FundamentalZone(new(Hamilton).Mul(inv, h))
+			h = new(Hamilton).Mul(mean, d)
+			var v [4]float64
+			v[0], v[1], v[2], v[3] = h.Cartesian()
+			for i := range v {
+				for j := range v {
+					m[i][j] += v[i] * v[j]
+				}
+			}
+		}
+		_, vecs := symEigen(m)
+		mean = positive(NewHamilton(vecs[0][0], vecs[1][0], vecs[2][0], vecs[3][0]))
+	}
+	return s.FundamentalZone(mean)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPointGroupOrders(t *testing.T) {
+	var tests = []struct {
+		name  string
+		s     PointGroup
+		order int
+	}{
+		{"CubicSymmetry", CubicSymmetry(), 24},
+		{"HexagonalSymmetry", HexagonalSymmetry(), 12},
+		{"TetragonalSymmetry", TetragonalSymmetry(), 8},
+		{"TrigonalSymmetry", TrigonalSymmetry(), 6},
+		{"OrthorhombicSymmetry", OrthorhombicSymmetry(), 4},
+		{"MonoclinicSymmetry", MonoclinicSymmetry(), 2},
+		{"TriclinicSymmetry", TriclinicSymmetry(), 1},
+	}
+	for _, tt := range tests {
+		if len(tt.s) != tt.order {
+			t.Errorf("%s has %d rotations, want %d", tt.name, len(tt.s), tt.order)
+		}
+		if got := len(GroupClosure(append(tt.s, NewHamilton(-1, 0, 0, 0)))); got != 2*tt.order {
+			t.Errorf("%s generates %d unit quaternions, want %d", tt.name, got, 2*tt.order)
+		}
+	}
+}
+
+func TestDisorientation(t *testing.T) {
+	s := CubicSymmetry()
+	z := Vec3{0, 0, 1}
+	var tests = []struct {
+		g1, g2 *Hamilton
+		θ      float64
+		axis   Vec3
+	}{
+		{oneH, RotationHamilton(z, math.Pi/3), math.Pi / 6, z},
+		{oneH, RotationHamilton(z, 50*math.Pi/180), 40 * math.Pi / 180, z},
+		{RotationHamilton(Vec3{1, 2, 3}, 1), RotationHamilton(Vec3{1, 2, 3}, 1), 0, Vec3{}},
+		{oneH, RotationHamilton(Vec3{1, 1, 1}, math.Pi/3), math.Pi / 3, Vec3{1, 1, 1}.Unit()},
+	}
+	for _, tt := range tests {
+		axis, θ := s.Disorientation(tt.g1, tt.g2)
+		if notEquals(θ, tt.θ) {
+			t.Errorf("Disorientation(%v, %v) angle = %v, want %v", tt.g1, tt.g2, θ, tt.θ)
+		}
+		for i := range axis {
+			if notEquals(axis[i], tt.axis[i]) {
+				t.Errorf("Disorientation(%v, %v) axis = %v, want %v", tt.g1, tt.g2, axis, tt.axis)
+				break
+			}
+		}
+	}
+}
+
+func TestDisorientationInvariance(t *testing.T) {
+	s := CubicSymmetry()
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 20; n++ {
+		g1 := new(Hamilton).Normalize(NewHamilton(r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()))
+		g2 := new(Hamilton).Normalize(NewHamilton(r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()))
+		_, θ := s.Disorientation(g1, g2)
+		if θ > 62.81*math.Pi/180 {
+			t.Errorf("cubic disorientation %v exceeds the Mackenzie limit", θ*180/math.Pi)
+		}
+		h1 := new(Hamilton).Mul(g1, s[r.Intn(len(s))])
+		h2 := new(Hamilton).Mul(g2, s[r.Intn(len(s))])
+		if got := s.Misorientation(h2, h1); !got.ApproxEquals(s.Misorientation(g1, g2)) {
+			t.Errorf("Misorientation(%v, %v) = %v, want %v", h2, h1, got, s.Misorientation(g1, g2))
+		}
+	}
+}
+
+func TestFundamentalZone(t *testing.T) {
+	s := HexagonalSymmetry()
+	g := RotationHamilton(Vec3{1, -2, 0.5}, 2.5)
+	f := s.FundamentalZone(g)
+	for _, h := range s {
+		if got := s.FundamentalZone(new(Hamilton).Mul(g, h)); !got.ApproxEquals(f) {
+			t.Errorf("FundamentalZone(%v) = %v, want %v", new(Hamilton).Mul(g, h), got, f)
+		}
+	}
+	_, θ := f.AxisAngle()
+	for _, h := range s {
+		if _, φ := new(Hamilton).Mul(f, h).AxisAngle(); math.Min(φ, 2*math.Pi-φ) < θ-delta {
+			t.Errorf("FundamentalZone(%v) = %v is not the smallest rotation", g, f)
+		}
+	}
+}
+
+func TestRodriguesFrank(t *testing.T) {
+	axis := Vec3{0, 1, 0}
+	v := RotationHamilton(axis, math.Pi/2).RodriguesFrank()
+	if notEquals(v[0], 0) || notEquals(v[1], 1) || notEquals(v[2], 0) {
+		t.Errorf("RodriguesFrank = %v, want %v", v, axis)
+	}
+	// A half-turn has an infinite Rodrigues-Frank vector.
+	if v := NewHamilton(0, 0, 1, 0).RodriguesFrank(); !math.IsInf(v[1], 1) {
+		t.Errorf("RodriguesFrank of a half-turn = %v", v)
+	}
+}
+
+func TestPointGroupMean(t *testing.T) {
+	s := CubicSymmetry()
+	g := RotationHamilton(Vec3{1, 2, 2}, 0.3)
+	r := rand.New(rand.NewSource(2))
+	var set []*Hamilton
+	for n := 0; n < 50; n++ {
+		e := RotationHamilton(Vec3{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}, 0.02*r.Float64())
+		h := new(Hamilton).Mul(g, e)
+		set = append(set, h.Mul(h, s[r.Intn(len(s))]))
+	}
+	m := s.Mean(set)
+	if _, θ := s.Disorientation(m, g); θ > 0.01 {
+		t.Errorf("Mean is %v away from %v", θ, g)
+	}
+}
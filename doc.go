@@ -2,7 +2,7 @@
 // Licenced under the MIT License.
 
 // Package quat implements arithmetic for Hamilton, Cockle, and Macfarlane
-// quaternions.
+// quaternions, and for dual Hamilton quaternions and biquaternions.
 package quat
 
 const delta = 0.00000001
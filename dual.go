@@ -0,0 +1,259 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"fmt"
+	"math"
+)
+
+// A DualHamilton represents a dual Hamilton quaternion (also known as a dual
+// quaternion) as an ordered array of two Hamilton values: the real part and
+// the dual part, which is the coefficient of a unit ε with ε*ε = 0.
+//
+// A unit DualHamilton value represents a rigid transformation. The value made
+// from a rotation q and a translation t is
+//
+//	DualHamilton{q, ½ t q}
+//
+// and it maps a point p to q.Rotate(p) + t.
+type DualHamilton [2]Hamilton
+
+// String returns the string representation of a DualHamilton value. If z has
+// real part p and dual part q, then the string is "(p+qε)", with p and q
+// formatted as Hamilton values.
+func (z *DualHamilton) String() string {
+	return fmt.Sprintf("(%v+%vε)", &z[0], &z[1])
+}
+
+// Equals returns true if y and z are equal.
+func (z *DualHamilton) Equals(y *DualHamilton) bool {
+	return z[0].Equals(&y[0]) && z[1].Equals(&y[1])
+}
+
+// Copy copies y onto z, and returns z.
+func (z *DualHamilton) Copy(y *DualHamilton) *DualHamilton {
+	z[0] = y[0]
+	z[1] = y[1]
+	return z
+}
+
+// NewDualHamilton returns a pointer to a DualHamilton value made from a given
+// real part and dual part.
+func NewDualHamilton(p, q *Hamilton) *DualHamilton {
+	z := new(DualHamilton)
+	z[0] = *p
+	z[1] = *q
+	return z
+}
+
+// Dil sets z equal to the dilation of y by a, and returns z.
+func (z *DualHamilton) Dil(y *DualHamilton, a float64) *DualHamilton {
+	z[0].Dil(&y[0], a)
+	z[1].Dil(&y[1], a)
+	return z
+}
+
+// Neg sets z equal to the negative of y, and returns z.
+func (z *DualHamilton) Neg(y *DualHamilton) *DualHamilton {
+	return z.Dil(y, -1)
+}
+
+// Conj sets z equal to the quaternion conjugate of y (i.e. the conjugate of
+// both parts), and returns z.
+func (z *DualHamilton) Conj(y *DualHamilton) *DualHamilton {
+	z[0].Conj(&y[0])
+	z[1].Conj(&y[1])
+	return z
+}
+
+// DualConj sets z equal to the dual conjugate of y (i.e. with the sign of the
+// dual part flipped), and returns z.
+func (z *DualHamilton) DualConj(y *DualHamilton) *DualHamilton {
+	z[0] = y[0]
+	z[1].Neg(&y[1])
+	return z
+}
+
+// Add sets z equal to the sum of x and y, and returns z.
+func (z *DualHamilton) Add(x, y *DualHamilton) *DualHamilton {
+	z[0].Add(&x[0], &y[0])
+	z[1].Add(&x[1], &y[1])
+	return z
+}
+
+// Sub sets z equal to the difference of x and y, and returns z.
+func (z *DualHamilton) Sub(x, y *DualHamilton) *DualHamilton {
+	z[0].Sub(&x[0], &y[0])
+	z[1].Sub(&x[1], &y[1])
+	return z
+}
+
+// Mul sets z equal to the product of x and y, and returns z. If x and y are
+// rigid transformations, then the product applies y first and x second.
+func (z *DualHamilton) Mul(x, y *DualHamilton) *DualHamilton {
+	p := new(DualHamilton).Copy(x)
+	q := new(DualHamilton).Copy(y)
+	z[0].Mul(&p[0], &q[0])
+	z[1].Add(
+		new(Hamilton).Mul(&p[0], &q[1]),
+		new(Hamilton).Mul(&p[1], &q[0]),
+	)
+	return z
+}
+
+// Quad returns the quadrance of z, which is a dual number a + bε with
+// a = Quad(z[0]) and b = 2 Dot(z[0], z[1]).
+func (z *DualHamilton) Quad() (a, b float64) {
+	return z[0].Quad(), 2 * dotHamilton(&z[0], &z[1])
+}
+
+// dotHamilton returns the Euclidean inner product of the components of x and
+// y.
+func dotHamilton(x, y *Hamilton) float64 {
+	a, b, c, d := x.Cartesian()
+	e, f, g, h := y.Cartesian()
+	return (a * e) + (b * f) + (c * g) + (d * h)
+}
+
+// Inv sets z equal to the inverse of y, and returns z. If the real part of y
+// is zero, then Inv panics.
+func (z *DualHamilton) Inv(y *DualHamilton) *DualHamilton {
+	if y[0].Equals(zeroH) {
+		panic("inverse of dual zero divisor")
+	}
+	p := new(Hamilton).Inv(&y[0])
+	q := new(Hamilton).Mul(p, &y[1])
+	q.Mul(q, p)
+	z[0] = *p
+	z[1].Neg(q)
+	return z
+}
+
+// Normalize sets z equal to the unit DualHamilton value closest to y, with
+// real part y[0]/|y[0]| and dual part orthogonal to it, and returns z. If the
+// real part of y is zero, then Normalize panics.
+func (z *DualHamilton) Normalize(y *DualHamilton) *DualHamilton {
+	if y[0].Equals(zeroH) {
+		panic("normalize of dual zero divisor")
+	}
+	n := math.Sqrt(y[0].Quad())
+	p := new(Hamilton).Dil(&y[0], 1/n)
+	q := new(Hamilton).Dil(&y[1], 1/n)
+	q.Sub(q, new(Hamilton).Dil(p, dotHamilton(p, q)))
+	z[0] = *p
+	z[1] = *q
+	return z
+}
+
+// RigidDualHamilton returns the unit DualHamilton value of the rigid
+// transformation that rotates by the unit Hamilton value q and then
+// translates by t.
+func RigidDualHamilton(q *Hamilton, t Vec3) *DualHamilton {
+	d := NewHamilton(0, t[0]/2, t[1]/2, t[2]/2)
+	return NewDualHamilton(q, d.Mul(d, q))
+}
+
+// Rigid returns the rotation and the translation of the unit DualHamilton
+// value z.
+func (z *DualHamilton) Rigid() (q *Hamilton, t Vec3) {
+	q = new(Hamilton).Copy(&z[0])
+	t = new(Hamilton).Mul(&z[1], new(Hamilton).Conj(q)).Vec().Scale(2)
+	return
+}
+
+// Transform returns the image of the point p under the rigid transformation
+// represented by the unit DualHamilton value z.
+func (z *DualHamilton) Transform(p Vec3) Vec3 {
+	q, t := z.Rigid()
+	return q.Rotate(p).Add(t)
+}
+
+// ScrewDualHamilton returns the unit DualHamilton value of the screw motion
+// that rotates by θ about the line with unit direction axis and moment m, and
+// translates by d along it.
+func ScrewDualHamilton(axis, m Vec3, θ, d float64) *DualHamilton {
+	s, c := math.Sincos(θ / 2)
+	l := axis.Scale(s)
+	u := m.Scale(s).Add(axis.Scale(c * d / 2))
+	return NewDualHamilton(
+		NewHamilton(c, l[0], l[1], l[2]),
+		NewHamilton(-s*d/2, u[0], u[1], u[2]),
+	)
+}
+
+// Screw returns the screw parameters of the unit DualHamilton value z: the
+// unit direction and the moment of the screw axis, the rotation angle θ about
+// it, and the translation d along it. For a pure translation, θ is zero, the
+// axis is the direction of the translation, and the moment is zero. For the
+// identity, all parameters are zero.
+func (z *DualHamilton) Screw() (axis, m Vec3, θ, d float64) {
+	q, t := z.Rigid()
+	axis, θ = q.AxisAngle()
+	if notEquals(math.Sin(θ/2), 0) {
+		d = t.Dot(axis)
+		m = t.Cross(axis).Add(t.Sub(axis.Scale(d)).Scale(1 / math.Tan(θ/2))).Scale(0.5)
+		return
+	}
+	θ = 0
+	if d = t.Norm(); d != 0 {
+		axis = t.Scale(1 / d)
+	}
+	if a, _, _, _ := q.Cartesian(); a < 0 {
+		// A rotation by 2π about any axis.
+		θ = 2 * math.Pi
+		axis = Vec3{1, 0, 0}
+		if d != 0 {
+			axis = t.Scale(1 / d)
+		}
+	}
+	return
+}
+
+// Pitch returns the ratio of the translation along the screw axis of z to
+// the rotation angle about it. If z is a pure translation, then the angle is
+// zero and Pitch returns +Inf, or NaN if z is the identity.
+func (z *DualHamilton) Pitch() float64 {
+	_, _, θ, d := z.Screw()
+	return d / θ
+}
+
+// ScLERP sets z equal to the screw linear interpolation between the unit
+// DualHamilton values x and y at parameter t, and returns z. The
+// interpolation follows the shorter of the two screw motions from x to y.
+func (z *DualHamilton) ScLERP(x, y *DualHamilton, t float64) *DualHamilton {
+	p := new(DualHamilton).Copy(y)
+	if dotHamilton(&x[0], &p[0]) < 0 {
+		p.Neg(p)
+	}
+	p.Mul(new(DualHamilton).Conj(x), p)
+	axis, m, θ, d := p.Screw()
+	if axis == (Vec3{}) {
+		return z.Copy(x)
+	}
+	return z.Mul(x, ScrewDualHamilton(axis, m, t*θ, t*d))
+}
+
+// DLB sets z equal to the dual quaternion linear blend of the unit
+// DualHamilton values x with weights w, and returns z. Each value is first
+// aligned with x[0], so that ±x[i] contribute the same transformation. If x
+// is empty, w and x have different lengths, or the blend has zero real part,
+// then DLB panics.
+func (z *DualHamilton) DLB(w []float64, x []*DualHamilton) *DualHamilton {
+	if len(x) == 0 {
+		panic("blend of no values")
+	}
+	if len(w) != len(x) {
+		panic("weights and values have different lengths")
+	}
+	b := new(DualHamilton)
+	for i, y := range x {
+		s := w[i]
+		if dotHamilton(&x[0][0], &y[0]) < 0 {
+			s = -s
+		}
+		b.Add(b, new(DualHamilton).Dil(y, s))
+	}
+	return z.Normalize(b)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// closeVec3 reports whether every component of v and w differ by at most tol.
+func closeVec3(v, w Vec3, tol float64) bool {
+	for i := range v {
+		if math.Abs(v[i]-w[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// closeDualHamilton reports whether every component of y and z differ by at
+// most a small tolerance.
+func closeDualHamilton(y, z *DualHamilton) bool {
+	return y[0].ApproxEquals(&z[0]) && y[1].ApproxEquals(&z[1])
+}
+
+func ExampleRigidDualHamilton() {
+	z := RigidDualHamilton(NewHamilton(1, 0, 0, 0), Vec3{2, 4, 6})
+	fmt.Println(z)
+	fmt.Println(z.Transform(Vec3{1, 1, 1}))
+	// Output:
+	// ((1+0i+0j+0k)+(0+1i+2j+3k)ε)
+	// [3 5 7]
+}
+
+func TestDualHamiltonMul(t *testing.T) {
+	x := RigidDualHamilton(RotationHamilton(Vec3{0, 0, 1}, math.Pi/2), Vec3{1, 0, 0})
+	y := RigidDualHamilton(RotationHamilton(Vec3{1, 1, 0}, 1), Vec3{0, 2, -1})
+	p := Vec3{0.5, -1, 2}
+	got := new(DualHamilton).Mul(x, y).Transform(p)
+	want := x.Transform(y.Transform(p))
+	if !closeVec3(got, want, 1e-12) {
+		t.Errorf("Mul(x, y).Transform(%v) = %v, want %v", p, got, want)
+	}
+}
+
+func TestDualHamiltonInv(t *testing.T) {
+	x := RigidDualHamilton(RotationHamilton(Vec3{1, 2, 3}, 2), Vec3{1, -2, 0.5})
+	got := new(DualHamilton).Mul(x, new(DualHamilton).Inv(x))
+	if !closeDualHamilton(got, NewDualHamilton(oneH, zeroH)) {
+		t.Errorf("Mul(x, Inv(x)) = %v, want identity", got)
+	}
+}
+
+func TestDualHamiltonRigid(t *testing.T) {
+	q := RotationHamilton(Vec3{-1, 0, 2}, 0.3)
+	v := Vec3{4, 5, 6}
+	p, u := RigidDualHamilton(q, v).Rigid()
+	if !p.ApproxEquals(q) || !closeVec3(u, v, 1e-12) {
+		t.Errorf("Rigid = %v, %v, want %v, %v", p, u, q, v)
+	}
+}
+
+func TestDualHamiltonNormalize(t *testing.T) {
+	x := RigidDualHamilton(RotationHamilton(Vec3{1, 0, 0}, 1), Vec3{1, 2, 3})
+	y := new(DualHamilton).Dil(x, 3)
+	y[1].Add(&y[1], new(Hamilton).Dil(&x[0], 0.2))
+	if got := new(DualHamilton).Normalize(y); !closeDualHamilton(got, x) {
+		t.Errorf("Normalize(%v) = %v, want %v", y, got, x)
+	}
+}
+
+func TestDualHamiltonScrew(t *testing.T) {
+	var tests = []*DualHamilton{
+		RigidDualHamilton(RotationHamilton(Vec3{0, 0, 1}, 1), Vec3{1, 0, 2}),
+		RigidDualHamilton(RotationHamilton(Vec3{1, 2, 3}, -2.5), Vec3{-1, 4, 0}),
+		RigidDualHamilton(NewHamilton(1, 0, 0, 0), Vec3{0, 3, 4}),
+	}
+	for _, z := range tests {
+		axis, m, θ, d := z.Screw()
+		if got := ScrewDualHamilton(axis, m, θ, d); !closeDualHamilton(got, z) {
+			t.Errorf("ScrewDualHamilton(Screw(%v)) = %v", z, got)
+		}
+	}
+	// A rotation by π/2 about the z axis through (1, 0, 0), with pitch 2/π.
+	z := RigidDualHamilton(RotationHamilton(Vec3{0, 0, 1}, math.Pi/2), Vec3{1, -1, 1})
+	axis, m, θ, d := z.Screw()
+	if !closeVec3(axis, Vec3{0, 0, 1}, 1e-12) || !closeVec3(m, Vec3{0, -1, 0}, 1e-12) ||
+		notEquals(θ, math.Pi/2) || notEquals(d, 1) {
+		t.Errorf("Screw(%v) = %v, %v, %v, %v", z, axis, m, θ, d)
+	}
+	if notEquals(z.Pitch(), 2/math.Pi) {
+		t.Errorf("Pitch(%v) = %v, want %v", z, z.Pitch(), 2/math.Pi)
+	}
+	// A pure translation has infinite pitch.
+	if p := tests[2].Pitch(); !math.IsInf(p, 1) {
+		t.Errorf("Pitch(%v) = %v, want +Inf", tests[2], p)
+	}
+}
+
+func TestDualHamiltonScLERP(t *testing.T) {
+	x := RigidDualHamilton(NewHamilton(1, 0, 0, 0), Vec3{})
+	y := RigidDualHamilton(RotationHamilton(Vec3{0, 0, 1}, math.Pi/2), Vec3{1, -1, 1})
+	z := new(DualHamilton)
+	if z.ScLERP(x, y, 0); !closeDualHamilton(z, x) {
+		t.Errorf("ScLERP at 0 = %v, want %v", z, x)
+	}
+	if z.ScLERP(x, y, 1); !closeDualHamilton(z, y) {
+		t.Errorf("ScLERP at 1 = %v, want %v", z, y)
+	}
+	// Halfway along the screw: a rotation by π/4 about the same axis.
+	want := RigidDualHamilton(RotationHamilton(Vec3{0, 0, 1}, math.Pi/4), Vec3{1 - math.Sqrt2/2, -math.Sqrt2 / 2, 0.5})
+	if z.ScLERP(x, y, 0.5); !closeDualHamilton(z, want) {
+		t.Errorf("ScLERP at 0.5 = %v, want %v", z, want)
+	}
+	if z.ScLERP(x, new(DualHamilton).Neg(y), 1); !closeDualHamilton(z, y) {
+		t.Errorf("ScLERP to -y at 1 = %v, want %v", z, y)
+	}
+}
+
+func TestDualHamiltonDLB(t *testing.T) {
+	x := RigidDualHamilton(RotationHamilton(Vec3{0, 1, 0}, 0.4), Vec3{1, 2, 3})
+	got := new(DualHamilton).DLB([]float64{0.3, 0.7}, []*DualHamilton{x, new(DualHamilton).Neg(x)})
+	if !closeDualHamilton(got, x) {
+		t.Errorf("DLB = %v, want %v", got, x)
+	}
+	y := RigidDualHamilton(RotationHamilton(Vec3{0, 1, 0}, 0.8), Vec3{1, 2, 3})
+	got.DLB([]float64{0.5, 0.5}, []*DualHamilton{x, y})
+	q, v := got.Rigid()
+	if angleBetweenRotations(q, RotationHamilton(Vec3{0, 1, 0}, 0.6)) > 1e-12 || !closeVec3(v, Vec3{1, 2, 3}, 1e-12) {
+		t.Errorf("DLB = %v", got)
+	}
+}
+
+func TestDualHamiltonDLBPanics(t *testing.T) {
+	x := RigidDualHamilton(RotationHamilton(Vec3{0, 1, 0}, 0.4), Vec3{1, 2, 3})
+	defer func() {
+		if recover() == nil {
+			t.Error("DLB with more values than weights did not panic")
+		}
+	}()
+	new(DualHamilton).DLB([]float64{1}, []*DualHamilton{x, x})
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// fft returns the unnormalized discrete Fourier transform of x, with kernel
+// exp(-2πi*k*n/N), or exp(+2πi*k*n/N) if inverse is true. Lengths that are
+// powers of two use the radix-2 algorithm, and other lengths use Bluestein's
+// algorithm. It leaves x unchanged.
+func fft(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	if n == 0 {
+		return nil
+	}
+	if n&(n-1) == 0 {
+		y := append([]complex128(nil), x...)
+		radix2(y, inverse)
+		return y
+	}
+	return bluestein(x, inverse)
+}
+
+// radix2 computes the discrete Fourier transform of x in place. The length of
+// x must be a power of two.
+func radix2(x []complex128, inverse bool) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	for size := 2; size <= n; size <<= 1 {
+		w := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			t := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				a, b := x[start+k], t*x[start+k+size/2]
+				x[start+k], x[start+k+size/2] = a+b, a-b
+				t *= w
+			}
+		}
+	}
+}
+
+// bluestein returns the discrete Fourier transform of x of any length, as a
+// convolution computed with radix-2 transforms.
+func bluestein(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	// The chirp w[k] = exp(±πi*k²/n), with k² reduced mod 2n for accuracy.
+	w := make([]complex128, n)
+	for k := range w {
+		w[k] = cmplx.Rect(1, sign*math.Pi*float64((k*k)%(2*n))/float64(n))
+	}
+	a := make([]complex128, m)
+	b := make([]complex128, m)
+	for k := 0; k < n; k++ {
+		a[k] = x[k] * w[k]
+	}
+	b[0] = cmplx.Conj(w[0])
+	for k := 1; k < n; k++ {
+		b[k] = cmplx.Conj(w[k])
+		b[m-k] = cmplx.Conj(w[k])
+	}
+	radix2(a, false)
+	radix2(b, false)
+	for k := range a {
+		a[k] *= b[k]
+	}
+	radix2(a, true)
+	y := make([]complex128, n)
+	for k := range y {
+		y[k] = a[k] * w[k] / complex(float64(m), 0)
+	}
+	return y
+}
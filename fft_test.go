@@ -0,0 +1,43 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// naiveDFT returns the discrete Fourier transform of x by direct summation.
+func naiveDFT(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	y := make([]complex128, n)
+	for k := range y {
+		for j, v := range x {
+			y[k] += v * cmplx.Rect(1, sign*2*math.Pi*float64(j*k)/float64(n))
+		}
+	}
+	return y
+}
+
+func TestFFT(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 8, 12, 16} {
+		x := make([]complex128, n)
+		for k := range x {
+			x[k] = complex(math.Sin(float64(k)), float64(k%3))
+		}
+		for _, inverse := range []bool{false, true} {
+			got, want := fft(x, inverse), naiveDFT(x, inverse)
+			for k := range got {
+				if cmplx.Abs(got[k]-want[k]) > 1e-9 {
+					t.Errorf("fft(n = %d, inverse = %v)[%d] = %v, want %v", n, inverse, k, got[k], want[k])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// maxGroupOrder is the largest group that GroupClosure will build.
+const maxGroupOrder = 1 << 16
+
+// indexHamilton returns the index of the first element of g that is
+// approximately equal to z, or -1 if there is none.
+func indexHamilton(g []*Hamilton, z *Hamilton) int {
+	for i, h := range g {
+		if h.ApproxEquals(z) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hamiltonCell is the side of the cells of a hamiltonIndex. It is much larger
+// than the tolerance of ApproxEquals, so that approximately equal values lie
+// in the same cell or in adjacent ones.
+const hamiltonCell = 1e-6
+
+// A hamiltonIndex is a list of Hamilton values with a map from grid cells to
+// the values in them, for finding approximately equal values in constant
+// time.
+type hamiltonIndex struct {
+	elems []*Hamilton
+	cells map[[4]int64][]int
+}
+
+// newHamiltonIndex returns a hamiltonIndex of the values g.
+func newHamiltonIndex(g []*Hamilton) *hamiltonIndex {
+	s := &hamiltonIndex{cells: make(map[[4]int64][]int)}
+	for _, z := range g {
+		s.add(z)
+	}
+	return s
+}
+
+// add appends z to s.
+func (s *hamiltonIndex) add(z *Hamilton) {
+	a, b, c, d := z.Cartesian()
+	var k [4]int64
+	for i, x := range [4]float64{a, b, c, d} {
+		k[i] = int64(math.Floor(x / hamiltonCell))
+	}
+	s.cells[k] = append(s.cells[k], len(s.elems))
+	s.elems = append(s.elems, z)
+}
+
+// find returns the index of the first value of s that is approximately equal
+// to z, or -1 if there is none. Only the cells within the tolerance of z are
+// searched.
+func (s *hamiltonIndex) find(z *Hamilton) int {
+	a, b, c, d := z.Cartesian()
+	var lo, hi [4]int64
+	for i, x := range [4]float64{a, b, c, d} {
+		lo[i] = int64(math.Floor((x - delta) / hamiltonCell))
+		hi[i] = int64(math.Floor((x + delta) / hamiltonCell))
+	}
+	best := -1
+	var k [4]int64
+	for k[0] = lo[0]; k[0] <= hi[0]; k[0]++ {
+		for k[1] = lo[1]; k[1] <= hi[1]; k[1]++ {
+			for k[2] = lo[2]; k[2] <= hi[2]; k[2]++ {
+				for k[3] = lo[3]; k[3] <= hi[3]; k[3]++ {
+					for _, i := range s.cells[k] {
+						if (best < 0 || i < best) && s.elems[i].ApproxEquals(z) {
+							best = i
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+// GroupClosure returns the group generated by a set of unit Hamilton values
+// under Mul. The first element is the identity, and elements that agree up to
+// a small tolerance are identified. If a generator is not a unit, or the
+// group has more than 65536 elements, then GroupClosure panics.
+func GroupClosure(generators []*Hamilton) []*Hamilton {
+	for _, s := range generators {
+		if notEquals(s.Quad(), 1) {
+			panic("generator is not a unit")
+		}
+	}
+	set := newHamiltonIndex([]*Hamilton{new(Hamilton).Copy(oneH)})
+	for n := 0; n < len(set.elems); n++ {
+		for _, s := range generators {
+			z := new(Hamilton).Mul(set.elems[n], s)
+			if set.find(z) < 0 {
+				if len(set.elems) == maxGroupOrder {
+					panic("group closure exceeds maximum order")
+				}
+				set.add(z)
+			}
+		}
+	}
+	return set.elems
+}
+
+// CyclicGroup returns the cyclic group of order n, generated by a rotation in
+// the complex plane spanned by 1 and i.
+func CyclicGroup(n int) []*Hamilton {
+	g := make([]*Hamilton, n)
+	for k := range g {
+		θ := 2 * math.Pi * float64(k) / float64(n)
+		g[k] = NewHamilton(math.Cos(θ), math.Sin(θ), 0, 0)
+	}
+	return g
+}
+
+// BinaryDihedralGroup returns the binary dihedral (or dicyclic) group of order
+// 4n, made from the cyclic group of order 2n and its product with j.
+func BinaryDihedralGroup(n int) []*Hamilton {
+	c := CyclicGroup(2 * n)
+	g := make([]*Hamilton, 0, 4*n)
+	g = append(g, c...)
+	for _, h := range c {
+		g = append(g, new(Hamilton).Mul(h, jH))
+	}
+	return g
+}
+
+// BinaryTetrahedralGroup returns the binary tetrahedral group of order 24. Its
+// elements are the vertices of the 24-cell.
+func BinaryTetrahedralGroup() []*Hamilton {
+	return GroupClosure([]*Hamilton{
+		NewHamilton(0, 1, 0, 0),
+		NewHamilton(0.5, 0.5, 0.5, 0.5),
+	})
+}
+
+// BinaryOctahedralGroup returns the binary octahedral group of order 48.
+func BinaryOctahedralGroup() []*Hamilton {
+	return GroupClosure([]*Hamilton{
+		NewHamilton(math.Sqrt2/2, math.Sqrt2/2, 0, 0),
+		NewHamilton(0.5, 0.5, 0.5, 0.5),
+	})
+}
+
+// BinaryIcosahedralGroup returns the binary icosahedral group of order 120.
+// Its elements are the vertices of the 600-cell.
+func BinaryIcosahedralGroup() []*Hamilton {
+	φ := (1 + math.Sqrt(5)) / 2
+	return GroupClosure([]*Hamilton{
+		NewHamilton(0.5, 0.5, 0.5, 0.5),
+		NewHamilton(φ/2, 1/(2*φ), 0.5, 0),
+	})
+}
+
+// CayleyTable returns the multiplication table of a finite group g, such that
+// g[t[m][n]] equals Mul(g[m], g[n]). If g is not closed under Mul, then
+// CayleyTable panics.
+func CayleyTable(g []*Hamilton) [][]int {
+	t := make([][]int, len(g))
+	set := newHamiltonIndex(g)
+	z := new(Hamilton)
+	for m, x := range g {
+		t[m] = make([]int, len(g))
+		for n, y := range g {
+			i := set.find(z.Mul(x, y))
+			if i < 0 {
+				panic("group is not closed")
+			}
+			t[m][n] = i
+		}
+	}
+	return t
+}
+
+// WriteCayleyTable writes a multiplication table to w, one row per line with
+// space-separated indices.
+func WriteCayleyTable(w io.Writer, t [][]int) error {
+	b := bufio.NewWriter(w)
+	for _, row := range t {
+		s := make([]string, len(row))
+		for n, v := range row {
+			s[n] = fmt.Sprint(v)
+		}
+		fmt.Fprintln(b, strings.Join(s, " "))
+	}
+	return b.Flush()
+}
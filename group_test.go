@@ -0,0 +1,94 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGroupOrders(t *testing.T) {
+	var tests = []struct {
+		name  string
+		g     []*Hamilton
+		order int
+	}{
+		{"CyclicGroup(5)", CyclicGroup(5), 5},
+		{"BinaryDihedralGroup(3)", BinaryDihedralGroup(3), 12},
+		{"BinaryTetrahedralGroup", BinaryTetrahedralGroup(), 24},
+		{"BinaryOctahedralGroup", BinaryOctahedralGroup(), 48},
+		{"BinaryIcosahedralGroup", BinaryIcosahedralGroup(), 120},
+	}
+	for _, tt := range tests {
+		if len(tt.g) != tt.order {
+			t.Errorf("%s has order %d, want %d", tt.name, len(tt.g), tt.order)
+		}
+		for _, h := range tt.g {
+			if notEquals(h.Quad(), 1) {
+				t.Errorf("%s contains %v, which is not a unit", tt.name, h)
+			}
+		}
+		if got := len(GroupClosure(tt.g)); got != tt.order {
+			t.Errorf("closure of %s has order %d, want %d", tt.name, got, tt.order)
+		}
+	}
+}
+
+func TestCayleyTable(t *testing.T) {
+	g := BinaryTetrahedralGroup()
+	table := CayleyTable(g)
+	for m, row := range table {
+		seen := make(map[int]bool)
+		for _, v := range row {
+			seen[v] = true
+		}
+		if len(seen) != len(g) {
+			t.Errorf("row %d of the Cayley table is not a permutation", m)
+		}
+	}
+	for n := range g {
+		if table[0][n] != n {
+			t.Errorf("identity row maps %d to %d", n, table[0][n])
+		}
+	}
+	var b bytes.Buffer
+	if err := WriteCayleyTable(&b, table); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(b.String(), "\n"); n != len(g) {
+		t.Errorf("written table has %d rows, want %d", n, len(g))
+	}
+}
+
+func TestCayleyTableNotClosed(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("CayleyTable did not panic on a set that is not a group")
+		}
+	}()
+	CayleyTable([]*Hamilton{oneH, iH})
+}
+
+func TestGroupClosurePanics(t *testing.T) {
+	var tests = []struct {
+		name string
+		g    *Hamilton
+	}{
+		{"non-unit generator", NewHamilton(2, 0, 0, 0)},
+		// exp(i) has infinite order.
+		{"infinite order", NewHamilton(math.Cos(1), math.Sin(1), 0, 0)},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("GroupClosure did not panic on a %s", tt.name)
+				}
+			}()
+			GroupClosure([]*Hamilton{tt.g})
+		}()
+	}
+}
@@ -87,6 +87,18 @@ func (z *Hamilton) Equals(y *Hamilton) bool {
 	return true
 }
 
+// ApproxEquals returns true if every component of y and z differ by at most
+// a small tolerance.
+func (z *Hamilton) ApproxEquals(y *Hamilton) bool {
+	if notEquals(real(z.Re()), real(y.Re())) || notEquals(imag(z.Re()), imag(y.Re())) {
+		return false
+	}
+	if notEquals(real(z.Im()), real(y.Im())) || notEquals(imag(z.Im()), imag(y.Im())) {
+		return false
+	}
+	return true
+}
+
 // Copy copies y onto z, and returns z.
 func (z *Hamilton) Copy(y *Hamilton) *Hamilton {
 	z.SetRe(y.Re())
@@ -268,3 +280,163 @@ func (z *Hamilton) Curv() (r, θ1, θ2, θ3 float64) {
 	θ3 = math.Atan2(imag(z.Im()), real(z.Im()))
 	return
 }
+
+// Vec returns the vector part of z, made from its i, j, and k components.
+func (z *Hamilton) Vec() Vec3 {
+	_, b, c, d := z.Cartesian()
+	return Vec3{b, c, d}
+}
+
+// Normalize sets z equal to y divided by its length, and returns z. If y is
+// zero, then Normalize panics.
+func (z *Hamilton) Normalize(y *Hamilton) *Hamilton {
+	if y.Equals(zeroH) {
+		panic("normalize of zero")
+	}
+	return z.Dil(y, 1/math.Sqrt(y.Quad()))
+}
+
+// RotationHamilton returns the unit Hamilton value that rotates vectors by an
+// angle θ (in radians, counterclockwise) about a given axis. If axis is zero,
+// then RotationHamilton panics.
+func RotationHamilton(axis Vec3, θ float64) *Hamilton {
+	n := axis.Unit().Scale(math.Sin(θ / 2))
+	return NewHamilton(math.Cos(θ/2), n[0], n[1], n[2])
+}
+
+// AxisAngle returns the unit axis and the angle (in the interval [0, 2π]) of
+// the rotation represented by z. If the angle vanishes, then the axis is zero.
+func (z *Hamilton) AxisAngle() (axis Vec3, θ float64) {
+	a, _, _, _ := z.Cartesian()
+	v := z.Vec()
+	n := v.Norm()
+	if n == 0 {
+		return Vec3{}, 0
+	}
+	return v.Scale(1 / n), 2 * math.Atan2(n, a)
+}
+
+// angleBetweenRotations returns the angle (in the interval [0, π]) of the
+// rotation from the unit Hamilton value p to q.
+func angleBetweenRotations(p, q *Hamilton) float64 {
+	d := new(Hamilton).Mul(new(Hamilton).Conj(p), q)
+	a, _, _, _ := d.Cartesian()
+	return 2 * math.Atan2(d.Vec().Norm(), math.Abs(a))
+}
+
+// Rotate returns the vector part of z*v*Inv(z), where v is viewed as a Hamilton
+// value with zero real part. If z is a unit, then this is the rotation of v
+// described by z. If z is zero, then Rotate panics.
+func (z *Hamilton) Rotate(v Vec3) Vec3 {
+	p := NewHamilton(0, v[0], v[1], v[2])
+	p.Mul(z, p)
+	p.Mul(p, new(Hamilton).Inv(z))
+	return p.Vec()
+}
+
+// Matrix3 returns the 3×3 real matrix of the map v ↦ z.Rotate(v). If z is a
+// unit, then this is the rotation matrix of z. If z is zero, then Matrix3
+// panics.
+func (z *Hamilton) Matrix3() Mat3 {
+	if z.Equals(zeroH) {
+		panic("rotation matrix of zero")
+	}
+	a, b, c, d := z.Cartesian()
+	n := z.Quad()
+	return Mat3{
+		{(a*a + b*b - c*c - d*d) / n, 2 * (b*c - a*d) / n, 2 * (b*d + a*c) / n},
+		{2 * (b*c + a*d) / n, (a*a - b*b + c*c - d*d) / n, 2 * (c*d - a*b) / n},
+		{2 * (b*d - a*c) / n, 2 * (c*d + a*b) / n, (a*a - b*b - c*c + d*d) / n},
+	}
+}
+
+// LeftMatrix returns the 4×4 real matrix of the map x ↦ z*x in the basis 1, i,
+// j, k.
+func (z *Hamilton) LeftMatrix() [4][4]float64 {
+	var m [4][4]float64
+	for n, e := range hamiltonBasis() {
+		a, b, c, d := new(Hamilton).Mul(z, e).Cartesian()
+		m[0][n], m[1][n], m[2][n], m[3][n] = a, b, c, d
+	}
+	return m
+}
+
+// RightMatrix returns the 4×4 real matrix of the map x ↦ x*z in the basis 1,
+// i, j, k.
+func (z *Hamilton) RightMatrix() [4][4]float64 {
+	var m [4][4]float64
+	for n, e := range hamiltonBasis() {
+		a, b, c, d := new(Hamilton).Mul(e, z).Cartesian()
+		m[0][n], m[1][n], m[2][n], m[3][n] = a, b, c, d
+	}
+	return m
+}
+
+// hamiltonBasis returns the basis elements 1, i, j, and k.
+func hamiltonBasis() [4]*Hamilton {
+	return [4]*Hamilton{
+		NewHamilton(1, 0, 0, 0),
+		NewHamilton(0, 1, 0, 0),
+		NewHamilton(0, 0, 1, 0),
+		NewHamilton(0, 0, 0, 1),
+	}
+}
+
+// Exp sets z equal to the exponential of y, and returns z. If y = a + v, then
+// the exponential is exp(a)(cos|v| + sin|v| v/|v|).
+func (z *Hamilton) Exp(y *Hamilton) *Hamilton {
+	a, _, _, _ := y.Cartesian()
+	v := y.Vec()
+	θ := v.Norm()
+	e := math.Exp(a)
+	if θ == 0 {
+		return z.Copy(NewHamilton(e, 0, 0, 0))
+	}
+	v = v.Scale(e * math.Sin(θ) / θ)
+	return z.Copy(NewHamilton(e*math.Cos(θ), v[0], v[1], v[2]))
+}
+
+// Log sets z equal to the principal logarithm of y, and returns z. If
+// y = a + v, then the logarithm is ln|y| + acos(a/|y|) v/|v|. For a negative
+// real y, the logarithm is ln|y| + πi. If y is zero, then Log panics.
+func (z *Hamilton) Log(y *Hamilton) *Hamilton {
+	if y.Equals(zeroH) {
+		panic("logarithm of zero")
+	}
+	a, _, _, _ := y.Cartesian()
+	v := y.Vec()
+	r := math.Sqrt(y.Quad())
+	n := v.Norm()
+	θ := math.Atan2(n, a)
+	if n == 0 {
+		if a < 0 {
+			return z.Copy(NewHamilton(math.Log(r), math.Pi, 0, 0))
+		}
+		return z.Copy(NewHamilton(math.Log(r), 0, 0, 0))
+	}
+	v = v.Scale(θ / n)
+	return z.Copy(NewHamilton(math.Log(r), v[0], v[1], v[2]))
+}
+
+// Slerp sets z equal to the spherical linear interpolation between the unit
+// Hamilton values x and y at parameter t, and returns z. The interpolation
+// follows the shorter arc, so that y and -y give the same rotations.
+func (z *Hamilton) Slerp(x, y *Hamilton, t float64) *Hamilton {
+	q := new(Hamilton).Copy(y)
+	c := dotHamilton(x, q)
+	if c < 0 {
+		q.Neg(q)
+		c = -c
+	}
+	if c > 1-delta {
+		// The values are almost equal, so interpolate linearly.
+		p := new(Hamilton).Dil(x, 1-t)
+		p.Add(p, q.Dil(q, t))
+		return z.Normalize(p)
+	}
+	θ := math.Acos(c)
+	s := math.Sin(θ)
+	p := new(Hamilton).Dil(x, math.Sin((1-t)*θ)/s)
+	p.Add(p, q.Dil(q, math.Sin(t*θ)/s))
+	return z.Copy(p)
+}
@@ -5,6 +5,7 @@ package quat
 
 import (
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -44,6 +45,23 @@ func ExampleRectHamilton() {}
 
 func TestHamiltonAdd(t *testing.T) {}
 
+func TestHamiltonApproxEquals(t *testing.T) {
+	x := NewHamilton(1, 2, 3, 4)
+	if !x.ApproxEquals(NewHamilton(1, 2, 3, 4+delta/2)) {
+		t.Errorf("%v is not approximately equal to a nearby value", x)
+	}
+	if x.ApproxEquals(NewHamilton(1, 2, 3.001, 4)) {
+		t.Errorf("%v is approximately equal to a distant value", x)
+	}
+}
+
+func TestHamiltonAxisAngle(t *testing.T) {
+	axis, θ := RotationHamilton(Vec3{0, 0, 2}, 1.5).AxisAngle()
+	if axis != (Vec3{0, 0, 1}) || notEquals(θ, 1.5) {
+		t.Errorf("AxisAngle = %v, %v, want (0, 0, 1), 1.5", axis, θ)
+	}
+}
+
 func TestHamiltonCommutator(t *testing.T) {}
 
 func TestHamiltonConj(t *testing.T) {}
@@ -64,12 +82,115 @@ func TestHamiltonMul(t *testing.T) {}
 
 func TestHamiltonNeg(t *testing.T) {}
 
+func TestHamiltonNormalize(t *testing.T) {
+	z := new(Hamilton).Normalize(NewHamilton(1, 1, 1, 1))
+	if !z.ApproxEquals(NewHamilton(0.5, 0.5, 0.5, 0.5)) {
+		t.Errorf("Normalize = %v, want (0.5+0.5i+0.5j+0.5k)", z)
+	}
+}
+
 func TestHamiltonQuad(t *testing.T) {}
 
 func TestHamiltonQuo(t *testing.T) {}
 
+func TestHamiltonRotate(t *testing.T) {
+	var tests = []struct {
+		z       *Hamilton
+		v, want Vec3
+	}{
+		{RotationHamilton(Vec3{0, 0, 1}, math.Pi/2), Vec3{1, 0, 0}, Vec3{0, 1, 0}},
+		{RotationHamilton(Vec3{1, 0, 0}, math.Pi/2), Vec3{0, 1, 0}, Vec3{0, 0, 1}},
+		{NewHamilton(2, 0, 0, 0), Vec3{1, 2, 3}, Vec3{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		got := tt.z.Rotate(tt.v)
+		for i := range got {
+			if notEquals(got[i], tt.want[i]) {
+				t.Errorf("Rotate(%v, %v) = %v, want %v", tt.z, tt.v, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestHamiltonMatrix3(t *testing.T) {
+	var tests = []*Hamilton{
+		RotationHamilton(Vec3{1, -2, 3}, 0.8),
+		NewHamilton(2, -1, 0.5, 3),
+	}
+	for _, z := range tests {
+		m := z.Matrix3()
+		for _, v := range []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0.3, -0.2, 0.7}} {
+			got, want := m.MulVec(v), z.Rotate(v)
+			for i := range got {
+				if notEquals(got[i], want[i]) {
+					t.Errorf("Matrix3(%v)*%v = %v, want %v", z, v, got, want)
+					break
+				}
+			}
+		}
+	}
+}
+
 func TestHamiltonScal(t *testing.T) {}
 
 func TestHamiltonString(t *testing.T) {}
 
 func TestHamiltonSub(t *testing.T) {}
+
+func TestHamiltonLeftRightMatrix(t *testing.T) {
+	x, y := NewHamilton(1, -2, 0.5, 3), NewHamilton(0, 1, 4, -1)
+	l, r := x.LeftMatrix(), x.RightMatrix()
+	a, b, c, d := y.Cartesian()
+	v := [4]float64{a, b, c, d}
+	for _, tt := range []struct {
+		m    [4][4]float64
+		want *Hamilton
+	}{
+		{l, new(Hamilton).Mul(x, y)},
+		{r, new(Hamilton).Mul(y, x)},
+	} {
+		var w [4]float64
+		for i := range w {
+			for j := range v {
+				w[i] += tt.m[i][j] * v[j]
+			}
+		}
+		if got := NewHamilton(w[0], w[1], w[2], w[3]); !got.ApproxEquals(tt.want) {
+			t.Errorf("matrix product = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestHamiltonExpLog(t *testing.T) {
+	var tests = []*Hamilton{
+		NewHamilton(1, 0, 0, 0),
+		NewHamilton(0.5, 1, -2, 0.3),
+		NewHamilton(-2, 0, 0, 0),
+		NewHamilton(0, 0, 0, 1),
+	}
+	for _, z := range tests {
+		if got := new(Hamilton).Exp(new(Hamilton).Log(z)); !got.ApproxEquals(z) {
+			t.Errorf("Exp(Log(%v)) = %v", z, got)
+		}
+	}
+	// exp(πi/2) = i
+	if got := new(Hamilton).Exp(NewHamilton(0, math.Pi/2, 0, 0)); !got.ApproxEquals(NewHamilton(0, 1, 0, 0)) {
+		t.Errorf("Exp(πi/2) = %v, want i", got)
+	}
+}
+
+func TestHamiltonSlerp(t *testing.T) {
+	x := NewHamilton(1, 0, 0, 0)
+	y := RotationHamilton(Vec3{0, 0, 1}, math.Pi/2)
+	if got, want := new(Hamilton).Slerp(x, y, 0.5), RotationHamilton(Vec3{0, 0, 1}, math.Pi/4); !got.ApproxEquals(want) {
+		t.Errorf("Slerp(1, %v, 0.5) = %v, want %v", y, got, want)
+	}
+	// The shorter arc to -y is the same as the one to y.
+	if got, want := new(Hamilton).Slerp(x, new(Hamilton).Neg(y), 0.25), RotationHamilton(Vec3{0, 0, 1}, math.Pi/8); !got.ApproxEquals(want) {
+		t.Errorf("Slerp(1, -y, 0.25) = %v, want %v", got, want)
+	}
+	if got := new(Hamilton).Slerp(x, x, 0.3); !got.ApproxEquals(x) {
+		t.Errorf("Slerp(x, x, 0.3) = %v, want %v", got, x)
+	}
+}
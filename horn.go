@@ -0,0 +1,221 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// A Similarity represents the transformation v ↦ Scale*Rot.Rotate(v) + Trans,
+// with Rot a unit Hamilton value.
+type Similarity struct {
+	Rot   *Hamilton
+	Trans Vec3
+	Scale float64
+}
+
+// Apply returns the image of v under s.
+func (s *Similarity) Apply(v Vec3) Vec3 {
+	return s.Rot.Rotate(v).Scale(s.Scale).Add(s.Trans)
+}
+
+// RMSE returns the root-mean-square distance between the images of src under
+// s and the corresponding points of dst.
+func (s *Similarity) RMSE(src, dst []Vec3) float64 {
+	var sum float64
+	for i, v := range src {
+		d := s.Apply(v).Sub(dst[i])
+		sum += d.Dot(d)
+	}
+	return math.Sqrt(sum / float64(len(src)))
+}
+
+// centroid returns the mean of a set of points.
+func centroid(p []Vec3) Vec3 {
+	var c Vec3
+	for _, v := range p {
+		c = c.Add(v)
+	}
+	return c.Scale(1 / float64(len(p)))
+}
+
+// AbsoluteOrientation returns the similarity that minimizes the sum of the
+// squared distances between the images of src and the corresponding points of
+// dst, using Horn's closed-form quaternion method. If withScale is false, then
+// the scale is fixed to 1. If src and dst have different lengths, or fewer
+// than three points, then AbsoluteOrientation panics.
+func AbsoluteOrientation(src, dst []Vec3, withScale bool) *Similarity {
+	if len(src) != len(dst) {
+		panic("point sets have different lengths")
+	}
+	if len(src) < 3 {
+		panic("fewer than three points")
+	}
+	cs, cd := centroid(src), centroid(dst)
+	obs := make([]Observation, len(src))
+	for i := range src {
+		obs[i] = Observation{Ref: src[i].Sub(cs), Body: dst[i].Sub(cd), Weight: 1}
+	}
+	k, _ := wahbaMatrix(obs)
+	m := make([][]float64, 4)
+	for i := range m {
+		m[i] = k[i][:]
+	}
+	vals, vecs := symEigen(m)
+	q := NewHamilton(vecs[0][0], vecs[1][0], vecs[2][0], vecs[3][0])
+	s := &Similarity{Rot: positive(q.Normalize(q)), Scale: 1}
+	if withScale {
+		var d float64
+		for _, o := range obs {
+			d += o.Ref.Dot(o.Ref)
+		}
+		s.Scale = vals[0] / d
+	}
+	s.Trans = cd.Sub(s.Rot.Rotate(cs).Scale(s.Scale))
+	return s
+}
+
+// AbsoluteOrientationRANSAC returns the similarity that best maps src onto
+// dst in the presence of outliers, along with the indices of the inliers. In
+// each of the given number of iterations, a similarity is fitted to three
+// random correspondences, and correspondences mapped within tol of their
+// target are counted as inliers. The final similarity is fitted to the
+// largest set of inliers. If fewer than three inliers are ever found, then
+// AbsoluteOrientationRANSAC returns the fit to all points. If src and dst have
+// different lengths, or fewer than three points, then
+// AbsoluteOrientationRANSAC panics.
+func AbsoluteOrientationRANSAC(src, dst []Vec3, withScale bool, tol float64, iters int, r *rand.Rand) (*Similarity, []int) {
+	if len(src) != len(dst) {
+		panic("point sets have different lengths")
+	}
+	if len(src) < 3 {
+		panic("fewer than three points")
+	}
+	inliers := func(s *Similarity) []int {
+		var in []int
+		for i, v := range src {
+			if s.Apply(v).Sub(dst[i]).Norm() <= tol {
+				in = append(in, i)
+			}
+		}
+		return in
+	}
+	var best []int
+	for n := 0; n < iters; n++ {
+		sample := r.Perm(len(src))[:3]
+		a, b := make([]Vec3, 3), make([]Vec3, 3)
+		for i, j := range sample {
+			a[i], b[i] = src[j], dst[j]
+		}
+		if a[1].Sub(a[0]).Cross(a[2].Sub(a[0])).Norm() == 0 {
+			continue
+		}
+		if in := inliers(AbsoluteOrientation(a, b, withScale)); len(in) > len(best) {
+			best = in
+		}
+	}
+	if len(best) < 3 {
+		s := AbsoluteOrientation(src, dst, withScale)
+		return s, inliers(s)
+	}
+	a, b := make([]Vec3, len(best)), make([]Vec3, len(best))
+	for i, j := range best {
+		a[i], b[i] = src[j], dst[j]
+	}
+	s := AbsoluteOrientation(a, b, withScale)
+	return s, inliers(s)
+}
+
+// A kdTree is a 3-d tree over a set of points, used for nearest neighbor
+// queries.
+type kdTree struct {
+	points []Vec3
+	index  []int // a permutation of the points, arranged as a balanced tree
+}
+
+// newKDTree returns a 3-d tree over p.
+func newKDTree(p []Vec3) *kdTree {
+	t := &kdTree{points: p, index: make([]int, len(p))}
+	for i := range t.index {
+		t.index[i] = i
+	}
+	t.build(t.index, 0)
+	return t
+}
+
+// build arranges idx so that its median along axis splits the rest.
+func (t *kdTree) build(idx []int, axis int) {
+	if len(idx) <= 1 {
+		return
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		return t.points[idx[i]][axis] < t.points[idx[j]][axis]
+	})
+	m := len(idx) / 2
+	t.build(idx[:m], (axis+1)%3)
+	t.build(idx[m+1:], (axis+1)%3)
+}
+
+// nearest returns the index of the point closest to v.
+func (t *kdTree) nearest(v Vec3) int {
+	best, bestDist := -1, math.Inf(1)
+	var search func(idx []int, axis int)
+	search = func(idx []int, axis int) {
+		if len(idx) == 0 {
+			return
+		}
+		m := len(idx) / 2
+		p := t.points[idx[m]]
+		if d := p.Sub(v); d.Dot(d) < bestDist {
+			best, bestDist = idx[m], d.Dot(d)
+		}
+		diff := v[axis] - p[axis]
+		near, far := idx[:m], idx[m+1:]
+		if diff > 0 {
+			near, far = far, near
+		}
+		search(near, (axis+1)%3)
+		if diff*diff < bestDist {
+			search(far, (axis+1)%3)
+		}
+	}
+	search(t.index, 0)
+	return best
+}
+
+// ICP returns the similarity that maps src onto the point cloud dst with the
+// iterative closest point algorithm, along with the final root-mean-square
+// distance. Correspondences are found with a k-d tree over dst, starting from
+// the identity, and the iteration stops when the distance improves by less
+// than tol or after maxIter iterations. If dst is empty, or src has fewer
+// than three points, then ICP panics.
+func ICP(src, dst []Vec3, withScale bool, maxIter int, tol float64) (*Similarity, float64) {
+	if len(dst) == 0 {
+		panic("point cloud is empty")
+	}
+	if len(src) < 3 {
+		panic("fewer than three points")
+	}
+	tree := newKDTree(dst)
+	s := &Similarity{Rot: NewHamilton(1, 0, 0, 0), Scale: 1}
+	match := make([]Vec3, len(src))
+	prev := math.Inf(1)
+	for iter := 0; iter < maxIter; iter++ {
+		for i, v := range src {
+			match[i] = dst[tree.nearest(s.Apply(v))]
+		}
+		err := s.RMSE(src, match)
+		if prev-err < tol {
+			return s, err
+		}
+		prev = err
+		s = AbsoluteOrientation(src, match, withScale)
+	}
+	for i, v := range src {
+		match[i] = dst[tree.nearest(s.Apply(v))]
+	}
+	return s, s.RMSE(src, match)
+}
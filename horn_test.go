@@ -0,0 +1,125 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// randomPoints returns n points with standard normal coordinates.
+func randomPoints(r *rand.Rand, n int) []Vec3 {
+	p := make([]Vec3, n)
+	for i := range p {
+		p[i] = Vec3{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+	}
+	return p
+}
+
+// closeSimilarity reports whether two similarities agree within tol.
+func closeSimilarity(s, t *Similarity, tol float64) bool {
+	if angleBetweenRotations(s.Rot, t.Rot) > tol || s.Trans.Sub(t.Trans).Norm() > tol {
+		return false
+	}
+	d := s.Scale - t.Scale
+	return -tol <= d && d <= tol
+}
+
+func TestAbsoluteOrientation(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	want := &Similarity{RotationHamilton(Vec3{1, -1, 2}, 2), Vec3{3, -1, 0.5}, 1.7}
+	src := randomPoints(r, 20)
+	dst := make([]Vec3, len(src))
+	for i, v := range src {
+		dst[i] = want.Apply(v)
+	}
+	if got := AbsoluteOrientation(src, dst, true); !closeSimilarity(got, want, 1e-9) {
+		t.Errorf("AbsoluteOrientation = %+v, want %+v", got, want)
+	}
+	want.Scale = 1
+	for i, v := range src {
+		dst[i] = want.Apply(v)
+	}
+	if got := AbsoluteOrientation(src, dst, false); !closeSimilarity(got, want, 1e-9) {
+		t.Errorf("AbsoluteOrientation = %+v, want %+v", got, want)
+	}
+}
+
+func TestAbsoluteOrientationRANSAC(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	want := &Similarity{RotationHamilton(Vec3{0, 1, 1}, -1), Vec3{1, 2, 3}, 1}
+	src := randomPoints(r, 40)
+	dst := make([]Vec3, len(src))
+	for i, v := range src {
+		dst[i] = want.Apply(v)
+		if i%4 == 0 {
+			dst[i] = dst[i].Add(Vec3{5 * r.NormFloat64(), 5 * r.NormFloat64(), 5})
+		}
+	}
+	got, in := AbsoluteOrientationRANSAC(src, dst, false, 1e-6, 50, r)
+	if !closeSimilarity(got, want, 1e-9) {
+		t.Errorf("AbsoluteOrientationRANSAC = %+v, want %+v", got, want)
+	}
+	if len(in) != 30 {
+		t.Errorf("AbsoluteOrientationRANSAC found %d inliers, want 30", len(in))
+	}
+}
+
+func TestICP(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	want := &Similarity{RotationHamilton(Vec3{1, 2, 3}, 0.1), Vec3{0.05, -0.02, 0.03}, 1}
+	dst := randomPoints(r, 200)
+	inv := &Similarity{new(Hamilton).Inv(want.Rot), Vec3{}, 1}
+	src := make([]Vec3, len(dst))
+	for i, v := range dst {
+		src[i] = inv.Apply(v.Sub(want.Trans))
+	}
+	r.Shuffle(len(src), func(i, j int) { src[i], src[j] = src[j], src[i] })
+	got, err := ICP(src, dst, false, 100, 1e-12)
+	if err > 1e-9 || !closeSimilarity(got, want, 1e-9) {
+		t.Errorf("ICP = %+v with error %v, want %+v", got, err, want)
+	}
+}
+
+func TestKDTreeNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	p := randomPoints(r, 100)
+	tree := newKDTree(p)
+	for _, v := range randomPoints(r, 50) {
+		want := 0
+		for i := range p {
+			if p[i].Sub(v).Norm() < p[want].Sub(v).Norm() {
+				want = i
+			}
+		}
+		if got := tree.nearest(v); got != want {
+			t.Errorf("nearest(%v) = %d, want %d", v, got, want)
+		}
+	}
+}
+
+func TestHornPanics(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	p := randomPoints(r, 5)
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"AbsoluteOrientationRANSAC with two points", func() {
+			AbsoluteOrientationRANSAC(p[:2], p[:2], false, 0.1, 10, r)
+		}},
+		{"ICP with an empty cloud", func() { ICP(p, nil, false, 10, 1e-9) }},
+		{"ICP with two points", func() { ICP(p[:2], p, false, 10, 1e-9) }},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.f()
+		}()
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// The Jacobians in this file are with respect to the components a, b, c, and
+// d of a Hamilton value a + bi + cj + dk, in that order. A Jacobian of a
+// Hamilton-valued function is a 4×4 matrix whose rows are the components of
+// the result, and a Jacobian of a Vec3-valued function is a 3×4 matrix.
+
+// MulJacobian returns the Jacobians of the product x*y with respect to x and
+// to y. Since the product is bilinear, these are the matrices of the right
+// multiplication by y and of the left multiplication by x.
+func MulJacobian(x, y *Hamilton) (dx, dy [4][4]float64) {
+	return y.RightMatrix(), x.LeftMatrix()
+}
+
+// NormalizeJacobian returns the Jacobian of y ↦ y/|y| at z, which is
+// (I - uuᵀ)/|z| with u = z/|z|. If z is zero, then NormalizeJacobian panics.
+func (z *Hamilton) NormalizeJacobian() [4][4]float64 {
+	if z.Equals(zeroH) {
+		panic("normalize of zero")
+	}
+	n := math.Sqrt(z.Quad())
+	a, b, c, d := z.Cartesian()
+	u := [4]float64{a / n, b / n, c / n, d / n}
+	var j [4][4]float64
+	for r := range j {
+		for s := range j[r] {
+			j[r][s] = -u[r] * u[s] / n
+		}
+		j[r][r] += 1 / n
+	}
+	return j
+}
+
+// Matrix3Jacobian returns the derivatives of z.Matrix3() with respect to the
+// components of z. With Matrix3 = M/N, where M is quadratic in the components
+// and N = Quad(z), the derivative along a component q is
+// (∂M/∂q)/N - 2q*Matrix3/N. If z is zero, then Matrix3Jacobian panics.
+func (z *Hamilton) Matrix3Jacobian() [4]Mat3 {
+	r := z.Matrix3()
+	a, b, c, d := z.Cartesian()
+	n := z.Quad()
+	dm := [4]Mat3{
+		{{a, -d, c}, {d, a, -b}, {-c, b, a}},
+		{{b, c, d}, {c, -b, -a}, {d, a, -b}},
+		{{-c, b, a}, {b, c, d}, {-a, d, -c}},
+		{{-d, -a, b}, {a, -d, c}, {b, c, d}},
+	}
+	q := [4]float64{a, b, c, d}
+	var j [4]Mat3
+	for k := range j {
+		j[k] = dm[k].Scale(2 / n).Sub(r.Scale(2 * q[k] / n))
+	}
+	return j
+}
+
+// RotateJacobian returns the Jacobians of z.Rotate(v) with respect to z and to
+// v. The Jacobian with respect to v is z.Matrix3(). If z is zero, then
+// RotateJacobian panics.
+func (z *Hamilton) RotateJacobian(v Vec3) (dz [3][4]float64, dv Mat3) {
+	for k, m := range z.Matrix3Jacobian() {
+		col := m.MulVec(v)
+		for i := range col {
+			dz[i][k] = col[i]
+		}
+	}
+	return dz, z.Matrix3()
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+// jacobianStep is the step of the central finite differences.
+const jacobianStep = 1e-6
+
+// numericJacobian returns the central finite difference Jacobian of f at z,
+// with a column for each component of z.
+func numericJacobian(f func(*Hamilton) []float64, z *Hamilton) [][]float64 {
+	var cols [4][]float64
+	for k, e := range hamiltonBasis() {
+		e.Dil(e, jacobianStep)
+		p := f(new(Hamilton).Add(z, e))
+		m := f(new(Hamilton).Sub(z, e))
+		cols[k] = make([]float64, len(p))
+		for i := range p {
+			cols[k][i] = (p[i] - m[i]) / (2 * jacobianStep)
+		}
+	}
+	j := make([][]float64, len(cols[0]))
+	for i := range j {
+		j[i] = []float64{cols[0][i], cols[1][i], cols[2][i], cols[3][i]}
+	}
+	return j
+}
+
+// hamiltonComponents returns the components of z as a slice.
+func hamiltonComponents(z *Hamilton) []float64 {
+	a, b, c, d := z.Cartesian()
+	return []float64{a, b, c, d}
+}
+
+// checkJacobian reports an error if the rows of got differ from want.
+func checkJacobian(t *testing.T, name string, got [][]float64, want [][]float64) {
+	t.Helper()
+	for i := range want {
+		for k := range want[i] {
+			if math.Abs(got[i][k]-want[i][k]) > 1e-6 {
+				t.Errorf("%s[%d][%d] = %v, want %v", name, i, k, got[i][k], want[i][k])
+			}
+		}
+	}
+}
+
+// rows4 returns the rows of a 4×4 matrix as slices.
+func rows4(m [4][4]float64) [][]float64 {
+	r := make([][]float64, 4)
+	for i := range r {
+		r[i] = m[i][:]
+	}
+	return r
+}
+
+var jacobianTestValues = []*Hamilton{
+	NewHamilton(1, 0, 0, 0),
+	RotationHamilton(Vec3{1, -2, 3}, 2.2),
+	NewHamilton(0.5, -1.5, 2, 0.25),
+}
+
+func TestMulJacobian(t *testing.T) {
+	y := NewHamilton(0.3, 1.2, -0.7, 2)
+	for _, x := range jacobianTestValues {
+		dx, dy := MulJacobian(x, y)
+		checkJacobian(t, "dx", rows4(dx), numericJacobian(func(z *Hamilton) []float64 {
+			return hamiltonComponents(new(Hamilton).Mul(z, y))
+		}, x))
+		checkJacobian(t, "dy", rows4(dy), numericJacobian(func(z *Hamilton) []float64 {
+			return hamiltonComponents(new(Hamilton).Mul(x, z))
+		}, y))
+	}
+}
+
+func TestNormalizeJacobian(t *testing.T) {
+	for _, z := range jacobianTestValues {
+		checkJacobian(t, "NormalizeJacobian", rows4(z.NormalizeJacobian()), numericJacobian(func(y *Hamilton) []float64 {
+			return hamiltonComponents(new(Hamilton).Normalize(y))
+		}, z))
+	}
+}
+
+func TestMatrix3Jacobian(t *testing.T) {
+	for _, z := range jacobianTestValues {
+		j := z.Matrix3Jacobian()
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 3; c++ {
+				got := [][]float64{{j[0][r][c], j[1][r][c], j[2][r][c], j[3][r][c]}}
+				want := numericJacobian(func(y *Hamilton) []float64 {
+					return []float64{y.Matrix3()[r][c]}
+				}, z)
+				checkJacobian(t, "Matrix3Jacobian", got, want)
+			}
+		}
+	}
+}
+
+func TestRotateJacobian(t *testing.T) {
+	v := Vec3{0.4, -1.3, 0.8}
+	for _, z := range jacobianTestValues {
+		dz, dv := z.RotateJacobian(v)
+		got := make([][]float64, 3)
+		for i := range got {
+			got[i] = dz[i][:]
+		}
+		checkJacobian(t, "dz", got, numericJacobian(func(y *Hamilton) []float64 {
+			r := y.Rotate(v)
+			return r[:]
+		}, z))
+		// Since Rotate is linear in v, its Jacobian is exact.
+		for k := 0; k < 3; k++ {
+			var e Vec3
+			e[k] = 1
+			col := z.Rotate(e)
+			for i := range col {
+				if notEquals(dv[i][k], col[i]) {
+					t.Errorf("dv[%d][%d] = %v, want %v", i, k, dv[i][k], col[i])
+				}
+			}
+		}
+	}
+}
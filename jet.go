@@ -0,0 +1,348 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Jet represents a real value together with its derivatives with respect
+// to N parameters, for forward-mode automatic differentiation. A nil Grad
+// means that all the derivatives vanish. The operations on jets return new
+// jets and never modify their arguments.
+type Jet struct {
+	Val  float64
+	Grad []float64
+}
+
+// ConstJet returns the jet of the constant a, with zero derivatives.
+func ConstJet(a float64) Jet {
+	return Jet{Val: a}
+}
+
+// VarJet returns the jet of the parameter number i of n, with value a.
+func VarJet(a float64, i, n int) Jet {
+	g := make([]float64, n)
+	g[i] = 1
+	return Jet{a, g}
+}
+
+// String returns the string version of x.
+func (x Jet) String() string {
+	return fmt.Sprintf("%g%v", x.Val, x.Grad)
+}
+
+// jetChain returns the jet with value v and gradient a*x.Grad + b*y.Grad.
+func jetChain(v, a float64, x Jet, b float64, y Jet) Jet {
+	n := len(x.Grad)
+	if len(y.Grad) > n {
+		n = len(y.Grad)
+	}
+	if n == 0 {
+		return Jet{Val: v}
+	}
+	g := make([]float64, n)
+	for i, d := range x.Grad {
+		g[i] += a * d
+	}
+	for i, d := range y.Grad {
+		g[i] += b * d
+	}
+	return Jet{v, g}
+}
+
+// Add returns the sum of x and y.
+func (x Jet) Add(y Jet) Jet {
+	return jetChain(x.Val+y.Val, 1, x, 1, y)
+}
+
+// Sub returns the difference of x and y.
+func (x Jet) Sub(y Jet) Jet {
+	return jetChain(x.Val-y.Val, 1, x, -1, y)
+}
+
+// Mul returns the product of x and y.
+func (x Jet) Mul(y Jet) Jet {
+	return jetChain(x.Val*y.Val, y.Val, x, x.Val, y)
+}
+
+// Quo returns the quotient of x and y.
+func (x Jet) Quo(y Jet) Jet {
+	v := x.Val / y.Val
+	return jetChain(v, 1/y.Val, x, -v/y.Val, y)
+}
+
+// Scale returns x scaled by a.
+func (x Jet) Scale(a float64) Jet {
+	return jetChain(a*x.Val, a, x, 0, Jet{})
+}
+
+// Neg returns the negative of x.
+func (x Jet) Neg() Jet {
+	return x.Scale(-1)
+}
+
+// apply returns f(x), with the derivative d = f'(x.Val).
+func (x Jet) apply(v, d float64) Jet {
+	return jetChain(v, d, x, 0, Jet{})
+}
+
+// Sqrt returns the square root of x.
+func (x Jet) Sqrt() Jet {
+	v := math.Sqrt(x.Val)
+	return x.apply(v, 0.5/v)
+}
+
+// Exp returns the exponential of x.
+func (x Jet) Exp() Jet {
+	v := math.Exp(x.Val)
+	return x.apply(v, v)
+}
+
+// Log returns the natural logarithm of x.
+func (x Jet) Log() Jet {
+	return x.apply(math.Log(x.Val), 1/x.Val)
+}
+
+// Sin returns the sine of x.
+func (x Jet) Sin() Jet {
+	return x.apply(math.Sin(x.Val), math.Cos(x.Val))
+}
+
+// Cos returns the cosine of x.
+func (x Jet) Cos() Jet {
+	return x.apply(math.Cos(x.Val), -math.Sin(x.Val))
+}
+
+// Atan2 returns the arc tangent of y/x, using the signs of the two to
+// determine the quadrant.
+func (y Jet) Atan2(x Jet) Jet {
+	r := x.Val*x.Val + y.Val*y.Val
+	return jetChain(math.Atan2(y.Val, x.Val), -y.Val/r, x, x.Val/r, y)
+}
+
+// A HamiltonJet represents a Hamilton value whose components a, b, c, and d
+// are jets, so that the arithmetic on it carries the derivatives of the
+// components with respect to N parameters. Its methods mirror those of
+// Hamilton.
+type HamiltonJet [4]Jet
+
+// NewHamiltonJet returns a pointer to the HamiltonJet a + bi + cj + dk.
+func NewHamiltonJet(a, b, c, d Jet) *HamiltonJet {
+	return &HamiltonJet{a, b, c, d}
+}
+
+// ConstHamiltonJet returns the HamiltonJet of the constant y.
+func ConstHamiltonJet(y *Hamilton) *HamiltonJet {
+	a, b, c, d := y.Cartesian()
+	return NewHamiltonJet(ConstJet(a), ConstJet(b), ConstJet(c), ConstJet(d))
+}
+
+// VarHamiltonJet returns the HamiltonJet whose components are the parameters
+// number i, i+1, i+2, and i+3 of n, with value y.
+func VarHamiltonJet(y *Hamilton, i, n int) *HamiltonJet {
+	a, b, c, d := y.Cartesian()
+	return NewHamiltonJet(VarJet(a, i, n), VarJet(b, i+1, n), VarJet(c, i+2, n), VarJet(d, i+3, n))
+}
+
+// String returns the string version of z.
+func (z *HamiltonJet) String() string {
+	return fmt.Sprintf("(%v + %vi + %vj + %vk)", z[0], z[1], z[2], z[3])
+}
+
+// Value returns the Hamilton value of z, without the derivatives.
+func (z *HamiltonJet) Value() *Hamilton {
+	return NewHamilton(z[0].Val, z[1].Val, z[2].Val, z[3].Val)
+}
+
+// Cartesian returns the four Cartesian components of z.
+func (z *HamiltonJet) Cartesian() (a, b, c, d Jet) {
+	return z[0], z[1], z[2], z[3]
+}
+
+// Copy copies y onto z, and returns z.
+func (z *HamiltonJet) Copy(y *HamiltonJet) *HamiltonJet {
+	*z = *y
+	return z
+}
+
+// Add sets z equal to the sum of x and y, and returns z.
+func (z *HamiltonJet) Add(x, y *HamiltonJet) *HamiltonJet {
+	for i := range z {
+		z[i] = x[i].Add(y[i])
+	}
+	return z
+}
+
+// Sub sets z equal to the difference of x and y, and returns z.
+func (z *HamiltonJet) Sub(x, y *HamiltonJet) *HamiltonJet {
+	for i := range z {
+		z[i] = x[i].Sub(y[i])
+	}
+	return z
+}
+
+// Neg sets z equal to the negative of y, and returns z.
+func (z *HamiltonJet) Neg(y *HamiltonJet) *HamiltonJet {
+	for i := range z {
+		z[i] = y[i].Neg()
+	}
+	return z
+}
+
+// Conj sets z equal to the conjugate of y, and returns z.
+func (z *HamiltonJet) Conj(y *HamiltonJet) *HamiltonJet {
+	z[0] = y[0]
+	for i := 1; i < 4; i++ {
+		z[i] = y[i].Neg()
+	}
+	return z
+}
+
+// Dil sets z equal to the dilation of y by the constant a, and returns z.
+func (z *HamiltonJet) Dil(y *HamiltonJet, a float64) *HamiltonJet {
+	for i := range z {
+		z[i] = y[i].Scale(a)
+	}
+	return z
+}
+
+// Scal sets z equal to y scaled by the real jet a, and returns z.
+func (z *HamiltonJet) Scal(y *HamiltonJet, a Jet) *HamiltonJet {
+	for i := range z {
+		z[i] = y[i].Mul(a)
+	}
+	return z
+}
+
+// Mul sets z equal to the product of x and y, and returns z.
+func (z *HamiltonJet) Mul(x, y *HamiltonJet) *HamiltonJet {
+	a1, b1, c1, d1 := x.Cartesian()
+	a2, b2, c2, d2 := y.Cartesian()
+	z[0] = a1.Mul(a2).Sub(b1.Mul(b2)).Sub(c1.Mul(c2)).Sub(d1.Mul(d2))
+	z[1] = a1.Mul(b2).Add(b1.Mul(a2)).Add(c1.Mul(d2)).Sub(d1.Mul(c2))
+	z[2] = a1.Mul(c2).Sub(b1.Mul(d2)).Add(c1.Mul(a2)).Add(d1.Mul(b2))
+	z[3] = a1.Mul(d2).Add(b1.Mul(c2)).Sub(c1.Mul(b2)).Add(d1.Mul(a2))
+	return z
+}
+
+// Quad returns the quadrance of z.
+func (z *HamiltonJet) Quad() Jet {
+	q := z[0].Mul(z[0])
+	for i := 1; i < 4; i++ {
+		q = q.Add(z[i].Mul(z[i]))
+	}
+	return q
+}
+
+// Inv sets z equal to the inverse of y, and returns z. If y is zero, then Inv
+// panics.
+func (z *HamiltonJet) Inv(y *HamiltonJet) *HamiltonJet {
+	q := y.Quad()
+	if q.Val == 0 {
+		panic("inverse of zero")
+	}
+	z.Conj(y)
+	for i := range z {
+		z[i] = z[i].Quo(q)
+	}
+	return z
+}
+
+// Quo sets z equal to the quotient x*Inv(y), and returns z. If y is zero, then
+// Quo panics.
+func (z *HamiltonJet) Quo(x, y *HamiltonJet) *HamiltonJet {
+	if y.Quad().Val == 0 {
+		panic("denominator is zero")
+	}
+	return z.Mul(x, new(HamiltonJet).Inv(y))
+}
+
+// Normalize sets z equal to y divided by its length, and returns z. If y is
+// zero, then Normalize panics.
+func (z *HamiltonJet) Normalize(y *HamiltonJet) *HamiltonJet {
+	q := y.Quad()
+	if q.Val == 0 {
+		panic("normalize of zero")
+	}
+	n := q.Sqrt()
+	for i := range z {
+		z[i] = y[i].Quo(n)
+	}
+	return z
+}
+
+// vecQuad returns the quadrance of the vector part of z.
+func (z *HamiltonJet) vecQuad() Jet {
+	return z[1].Mul(z[1]).Add(z[2].Mul(z[2])).Add(z[3].Mul(z[3]))
+}
+
+// Exp sets z equal to the exponential of y, and returns z. If y = a + v, then
+// the exponential is exp(a)(cos|v| + sin|v| v/|v|). Near v = 0, the factors
+// cos|v| and sin|v|/|v| are computed from their Taylor series in |v|², so that
+// the derivatives stay finite.
+func (z *HamiltonJet) Exp(y *HamiltonJet) *HamiltonJet {
+	e := y[0].Exp()
+	θ2 := y.vecQuad()
+	var c, s Jet
+	if θ2.Val < smallAngle*smallAngle {
+		// cos θ = 1 - θ²/2 + θ⁴/24 - θ⁶/720 and
+		// sin θ/θ = 1 - θ²/6 + θ⁴/120 - θ⁶/5040.
+		θ4 := θ2.Mul(θ2)
+		θ6 := θ4.Mul(θ2)
+		c = ConstJet(1).Sub(θ2.Scale(1.0 / 2)).Add(θ4.Scale(1.0 / 24)).Sub(θ6.Scale(1.0 / 720))
+		s = ConstJet(1).Sub(θ2.Scale(1.0 / 6)).Add(θ4.Scale(1.0 / 120)).Sub(θ6.Scale(1.0 / 5040))
+	} else {
+		θ := θ2.Sqrt()
+		c, s = θ.Cos(), θ.Sin().Quo(θ)
+	}
+	es := e.Mul(s)
+	v1, v2, v3 := y[1].Mul(es), y[2].Mul(es), y[3].Mul(es)
+	z[0], z[1], z[2], z[3] = e.Mul(c), v1, v2, v3
+	return z
+}
+
+// Log sets z equal to the principal logarithm of y, and returns z. If
+// y = a + v, then the logarithm is ln|y| + atan2(|v|, a) v/|v|. Near v = 0
+// with a > 0, the factor atan2(|v|, a)/|v| is computed from its Taylor series
+// in |v|²/a², so that the derivatives stay finite. For a negative real y, the
+// logarithm is ln|y| + πi as for Hamilton, and the derivatives of its vector
+// part are those of the constant πi. If y is zero, then Log panics.
+func (z *HamiltonJet) Log(y *HamiltonJet) *HamiltonJet {
+	q := y.Quad()
+	if q.Val == 0 {
+		panic("logarithm of zero")
+	}
+	l := q.Log().Scale(0.5)
+	a := y[0]
+	n2 := y.vecQuad()
+	if n2.Val == 0 && a.Val < 0 {
+		z[0], z[1], z[2], z[3] = l, ConstJet(math.Pi), ConstJet(0), ConstJet(0)
+		return z
+	}
+	var f Jet
+	if a.Val > 0 && n2.Val < smallAngle*smallAngle*a.Val*a.Val {
+		// With t = |v|/a, atan(t)/|v| = (1 - t²/3 + t⁴/5 - t⁶/7)/a.
+		t2 := n2.Quo(a.Mul(a))
+		t4 := t2.Mul(t2)
+		f = ConstJet(1).Sub(t2.Scale(1.0 / 3)).Add(t4.Scale(1.0 / 5)).Sub(t4.Mul(t2).Scale(1.0 / 7)).Quo(a)
+	} else {
+		n := n2.Sqrt()
+		f = n.Atan2(a).Quo(n)
+	}
+	v1, v2, v3 := y[1].Mul(f), y[2].Mul(f), y[3].Mul(f)
+	z[0], z[1], z[2], z[3] = l, v1, v2, v3
+	return z
+}
+
+// Rotate returns the vector part of z*v*Inv(z), where v is viewed as a
+// HamiltonJet with zero real part. If z is a unit, then this is the rotation
+// of v described by z. If z is zero, then Rotate panics.
+func (z *HamiltonJet) Rotate(v [3]Jet) [3]Jet {
+	p := NewHamiltonJet(ConstJet(0), v[0], v[1], v[2])
+	p.Mul(z, p)
+	p.Mul(p, new(HamiltonJet).Inv(z))
+	return [3]Jet{p[1], p[2], p[3]}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+// jetJacobian returns the Jacobian of the components of z with respect to the
+// parameters.
+func jetJacobian(z []Jet, n int) [][]float64 {
+	j := make([][]float64, len(z))
+	for i := range z {
+		j[i] = make([]float64, n)
+		copy(j[i], z[i].Grad)
+	}
+	return j
+}
+
+// checkJetValue reports an error if the value of z differs from want.
+func checkJetValue(t *testing.T, name string, z *HamiltonJet, want *Hamilton) {
+	t.Helper()
+	if got := z.Value(); !got.ApproxEquals(want) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestJet(t *testing.T) {
+	x, y := VarJet(0.7, 0, 2), VarJet(-1.3, 1, 2)
+	tests := []struct {
+		name string
+		got  Jet
+		f    func(a, b float64) float64
+	}{
+		{"Add", x.Add(y), func(a, b float64) float64 { return a + b }},
+		{"Sub", x.Sub(y), func(a, b float64) float64 { return a - b }},
+		{"Mul", x.Mul(y), func(a, b float64) float64 { return a * b }},
+		{"Quo", x.Quo(y), func(a, b float64) float64 { return a / b }},
+		{"Scale", x.Scale(3), func(a, b float64) float64 { return 3 * a }},
+		{"Sqrt", x.Sqrt(), func(a, b float64) float64 { return math.Sqrt(a) }},
+		{"Exp", y.Exp(), func(a, b float64) float64 { return math.Exp(b) }},
+		{"Log", x.Log(), func(a, b float64) float64 { return math.Log(a) }},
+		{"Sin", y.Sin(), func(a, b float64) float64 { return math.Sin(b) }},
+		{"Cos", y.Cos(), func(a, b float64) float64 { return math.Cos(b) }},
+		{"Atan2", y.Atan2(x), func(a, b float64) float64 { return math.Atan2(b, a) }},
+	}
+	const h = 1e-6
+	for _, test := range tests {
+		if want := test.f(x.Val, y.Val); notEquals(test.got.Val, want) {
+			t.Errorf("%s = %v, want %v", test.name, test.got.Val, want)
+		}
+		da := (test.f(x.Val+h, y.Val) - test.f(x.Val-h, y.Val)) / (2 * h)
+		db := (test.f(x.Val, y.Val+h) - test.f(x.Val, y.Val-h)) / (2 * h)
+		if math.Abs(test.got.Grad[0]-da) > 1e-6 || math.Abs(test.got.Grad[1]-db) > 1e-6 {
+			t.Errorf("%s gradient = %v, want [%v %v]", test.name, test.got.Grad, da, db)
+		}
+	}
+	// Constants have no gradient.
+	if c := ConstJet(2).Mul(ConstJet(3)); c.Grad != nil || c.Val != 6 {
+		t.Errorf("ConstJet(2)*ConstJet(3) = %v", c)
+	}
+}
+
+func TestHamiltonJetMul(t *testing.T) {
+	x := NewHamilton(0.5, -1.5, 2, 0.25)
+	y := NewHamilton(0.3, 1.2, -0.7, 2)
+	z := new(HamiltonJet).Mul(VarHamiltonJet(x, 0, 8), VarHamiltonJet(y, 4, 8))
+	checkJetValue(t, "Mul", z, new(Hamilton).Mul(x, y))
+	dx, dy := MulJacobian(x, y)
+	j := jetJacobian(z[:], 8)
+	for i := 0; i < 4; i++ {
+		checkJacobian(t, "Mul dx", [][]float64{j[i][:4]}, [][]float64{dx[i][:]})
+		checkJacobian(t, "Mul dy", [][]float64{j[i][4:]}, [][]float64{dy[i][:]})
+	}
+}
+
+func TestHamiltonJetUnary(t *testing.T) {
+	values := []*Hamilton{
+		NewHamilton(0.5, -1.5, 2, 0.25),
+		RotationHamilton(Vec3{1, -2, 3}, 2.2),
+		// A vector part below smallAngle uses the Taylor series.
+		NewHamilton(0.8, 1e-3, -2e-3, 5e-4),
+		NewHamilton(-0.8, 0.1, -0.2, 0.05),
+		NewHamilton(1.2, 0, 0, 0),
+	}
+	tests := []struct {
+		name string
+		jet  func(z *HamiltonJet) *HamiltonJet
+		f    func(z *Hamilton) *Hamilton
+	}{
+		{"Inv", func(z *HamiltonJet) *HamiltonJet { return new(HamiltonJet).Inv(z) }, func(z *Hamilton) *Hamilton { return new(Hamilton).Inv(z) }},
+		{"Normalize", func(z *HamiltonJet) *HamiltonJet { return new(HamiltonJet).Normalize(z) }, func(z *Hamilton) *Hamilton { return new(Hamilton).Normalize(z) }},
+		{"Exp", func(z *HamiltonJet) *HamiltonJet { return new(HamiltonJet).Exp(z) }, func(z *Hamilton) *Hamilton { return new(Hamilton).Exp(z) }},
+		{"Log", func(z *HamiltonJet) *HamiltonJet { return new(HamiltonJet).Log(z) }, func(z *Hamilton) *Hamilton { return new(Hamilton).Log(z) }},
+		{"Quo", func(z *HamiltonJet) *HamiltonJet {
+			return new(HamiltonJet).Quo(ConstHamiltonJet(NewHamilton(1, 2, 3, 4)), z)
+		}, func(z *Hamilton) *Hamilton { return new(Hamilton).Quo(NewHamilton(1, 2, 3, 4), z) }},
+	}
+	for _, test := range tests {
+		for _, x := range values {
+			z := test.jet(VarHamiltonJet(x, 0, 4))
+			checkJetValue(t, test.name, z, test.f(x))
+			want := numericJacobian(func(y *Hamilton) []float64 {
+				return hamiltonComponents(test.f(y))
+			}, x)
+			checkJacobian(t, test.name, jetJacobian(z[:], 4), want)
+		}
+	}
+	// Quad and NormalizeJacobian.
+	x := values[0]
+	q := VarHamiltonJet(x, 0, 4).Quad()
+	a, b, c, d := x.Cartesian()
+	if notEquals(q.Val, x.Quad()) || notEquals(q.Grad[0], 2*a) || notEquals(q.Grad[1], 2*b) ||
+		notEquals(q.Grad[2], 2*c) || notEquals(q.Grad[3], 2*d) {
+		t.Errorf("Quad = %v", q)
+	}
+	n := new(HamiltonJet).Normalize(VarHamiltonJet(x, 0, 4))
+	checkJacobian(t, "Normalize", jetJacobian(n[:], 4), rows4(x.NormalizeJacobian()))
+}
+
+func TestHamiltonJetExpLogIdentity(t *testing.T) {
+	// At zero and at one, Exp and Log have the finite derivatives of their
+	// Taylor series.
+	e := new(HamiltonJet).Exp(VarHamiltonJet(NewHamilton(0, 0, 0, 0), 0, 4))
+	want := [][]float64{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+	checkJacobian(t, "Exp at zero", jetJacobian(e[:], 4), want)
+	l := new(HamiltonJet).Log(VarHamiltonJet(NewHamilton(1, 0, 0, 0), 0, 4))
+	checkJacobian(t, "Log at one", jetJacobian(l[:], 4), want)
+}
+
+func TestHamiltonJetRotate(t *testing.T) {
+	x := NewHamilton(0.5, -1.5, 2, 0.25)
+	v := Vec3{0.4, -1.3, 0.8}
+	z := VarHamiltonJet(x, 0, 7)
+	w := z.Rotate([3]Jet{VarJet(v[0], 4, 7), VarJet(v[1], 5, 7), VarJet(v[2], 6, 7)})
+	dz, dv := x.RotateJacobian(v)
+	want := x.Rotate(v)
+	for i := range w {
+		if notEquals(w[i].Val, want[i]) {
+			t.Errorf("Rotate = %v, want %v", w, want)
+		}
+		checkJacobian(t, "Rotate dz", [][]float64{w[i].Grad[:4]}, [][]float64{dz[i][:]})
+		checkJacobian(t, "Rotate dv", [][]float64{w[i].Grad[4:]}, [][]float64{dv[i][:]})
+	}
+}
+
+func TestHamiltonJetCost(t *testing.T) {
+	// The gradient of a pose cost written with the HamiltonJet API matches
+	// finite differences of the same cost written with the Hamilton API.
+	points := []Vec3{{1, 0, 0}, {0, 2, 1}, {-1, 1, 3}}
+	truth := RotationHamilton(Vec3{1, 1, 0}, 0.6)
+	cost := func(q *Hamilton) float64 {
+		var c float64
+		for _, p := range points {
+			r := new(Hamilton).Normalize(q).Rotate(p).Sub(truth.Rotate(p))
+			c += r.Dot(r)
+		}
+		return c
+	}
+	x := NewHamilton(0.9, 0.2, -0.1, 0.3)
+	z := new(HamiltonJet).Normalize(VarHamiltonJet(x, 0, 4))
+	c := ConstJet(0)
+	for _, p := range points {
+		w := z.Rotate([3]Jet{ConstJet(p[0]), ConstJet(p[1]), ConstJet(p[2])})
+		s := truth.Rotate(p)
+		for i := range w {
+			r := w[i].Sub(ConstJet(s[i]))
+			c = c.Add(r.Mul(r))
+		}
+	}
+	if notEquals(c.Val, cost(x)) {
+		t.Errorf("cost = %v, want %v", c.Val, cost(x))
+	}
+	want := numericJacobian(func(y *Hamilton) []float64 {
+		return []float64{cost(y)}
+	}, x)
+	checkJacobian(t, "cost", [][]float64{c.Grad}, want)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+// The functions in this file describe the motion of a unit Hamilton value q
+// that rotates body vectors into the world frame. If ω is the angular
+// velocity in the body frame, then dq/dt = ½q*ω, and if ω is the angular
+// velocity in the world frame, then dq/dt = ½ω*q, where ω is viewed as a
+// Hamilton value with zero real part. The integrators advance q along the
+// sphere of unit Hamilton values instead of along straight lines, so that it
+// does not drift away from it.
+
+// BodyDerivative returns the derivative ½q*ω of q for the body angular
+// velocity ω.
+func BodyDerivative(q *Hamilton, ω Vec3) *Hamilton {
+	d := NewHamilton(0, ω[0]/2, ω[1]/2, ω[2]/2)
+	return d.Mul(q, d)
+}
+
+// WorldDerivative returns the derivative ½ω*q of q for the world angular
+// velocity ω.
+func WorldDerivative(q *Hamilton, ω Vec3) *Hamilton {
+	d := NewHamilton(0, ω[0]/2, ω[1]/2, ω[2]/2)
+	return d.Mul(d, q)
+}
+
+// BodyRate returns the body angular velocity, which is the vector part of
+// 2*Conj(q)*dq, of the unit Hamilton value q with derivative dq.
+func BodyRate(q, dq *Hamilton) Vec3 {
+	return new(Hamilton).Mul(new(Hamilton).Conj(q), dq).Vec().Scale(2)
+}
+
+// WorldRate returns the world angular velocity, which is the vector part of
+// 2*dq*Conj(q), of the unit Hamilton value q with derivative dq.
+func WorldRate(q, dq *Hamilton) Vec3 {
+	return new(Hamilton).Mul(dq, new(Hamilton).Conj(q)).Vec().Scale(2)
+}
+
+// BodyRateBetween returns the constant body angular velocity that carries the
+// unit Hamilton value p to q in the time dt, which is the rotation vector of
+// Conj(p)*q divided by dt. Since q and -q are the same rotation, the rotation
+// by less than π is chosen.
+func BodyRateBetween(p, q *Hamilton, dt float64) Vec3 {
+	return LogMap(new(Hamilton).Mul(new(Hamilton).Conj(p), q)).Scale(1 / dt)
+}
+
+// WorldRateBetween returns the constant world angular velocity that carries
+// the unit Hamilton value p to q in the time dt, which is the rotation vector
+// of q*Conj(p) divided by dt.
+func WorldRateBetween(p, q *Hamilton, dt float64) Vec3 {
+	return LogMap(new(Hamilton).Mul(q, new(Hamilton).Conj(p))).Scale(1 / dt)
+}
+
+// ExpStep returns q advanced by dt at the constant body angular velocity ω,
+// which is q*Exp(ωdt/2). The step is exact.
+func ExpStep(q *Hamilton, ω Vec3, dt float64) *Hamilton {
+	return BoxPlus(q, ω.Scale(dt))
+}
+
+// A RateFunc returns the body angular velocity at the time t and the
+// attitude q.
+type RateFunc func(t float64, q *Hamilton) Vec3
+
+// A Stepper advances the attitude q at the time t by the time step dt, with
+// the body angular velocity given by f.
+type Stepper func(f RateFunc, t float64, q *Hamilton, dt float64) *Hamilton
+
+// RK4Step is the classical fourth-order Runge-Kutta Stepper for dq/dt = ½q*ω,
+// applied to the components of q and followed by a normalization.
+func RK4Step(f RateFunc, t float64, q *Hamilton, dt float64) *Hamilton {
+	stage := func(s float64, k *Hamilton, h float64) *Hamilton {
+		y := new(Hamilton).Add(q, new(Hamilton).Dil(k, h))
+		return BodyDerivative(y, f(s, y))
+	}
+	k1 := BodyDerivative(q, f(t, q))
+	k2 := stage(t+dt/2, k1, dt/2)
+	k3 := stage(t+dt/2, k2, dt/2)
+	k4 := stage(t+dt, k3, dt)
+	s := new(Hamilton).Add(k1, k4)
+	s.Add(s, new(Hamilton).Dil(new(Hamilton).Add(k2, k3), 2))
+	p := new(Hamilton).Add(q, s.Dil(s, dt/6))
+	return p.Normalize(p)
+}
+
+// CG3Step is the third-order Crouch-Grossman Stepper. Each stage is a
+// product of exact exponential steps at the angular velocities of the
+// previous stages, so that the result stays a unit up to rounding.
+func CG3Step(f RateFunc, t float64, q *Hamilton, dt float64) *Hamilton {
+	const (
+		a21, a31, a32 = 3.0 / 4, 119.0 / 216, 17.0 / 108
+		b1, b2, b3    = 13.0 / 51, -2.0 / 3, 24.0 / 17
+		c2, c3        = 3.0 / 4, 17.0 / 24
+	)
+	ω1 := f(t, q)
+	y2 := ExpStep(q, ω1, a21*dt)
+	ω2 := f(t+c2*dt, y2)
+	y3 := ExpStep(ExpStep(q, ω1, a31*dt), ω2, a32*dt)
+	ω3 := f(t+c3*dt, y3)
+	return ExpStep(ExpStep(ExpStep(q, ω1, b1*dt), ω2, b2*dt), ω3, b3*dt)
+}
+
+// RKMK4Step is the fourth-order Runge-Kutta-Munthe-Kaas Stepper. It applies
+// the classical Runge-Kutta method to the rotation vector u of q(t) =
+// q*Exp(u(t)/2), which lives in a vector space, and maps the result back with
+// the exponential.
+func RKMK4Step(f RateFunc, t float64, q *Hamilton, dt float64) *Hamilton {
+	stage := func(s float64, u Vec3) Vec3 {
+		y := new(Hamilton).Mul(q, ExpMap(u))
+		return RightJacobianInv(u).MulVec(f(s, y)).Scale(dt)
+	}
+	k1 := stage(t, Vec3{})
+	k2 := stage(t+dt/2, k1.Scale(0.5))
+	k3 := stage(t+dt/2, k2.Scale(0.5))
+	k4 := stage(t+dt, k3)
+	u := k1.Add(k2.Scale(2)).Add(k3.Scale(2)).Add(k4).Scale(1.0 / 6)
+	return BoxPlus(q, u)
+}
+
+// IntegrateAttitude returns the attitude series of n steps of size dt from
+// the attitude q at the time t0, made with the Stepper step and the body
+// angular velocity f. The series includes the initial attitude.
+func IntegrateAttitude(step Stepper, f RateFunc, q *Hamilton, t0, dt float64, n int) AttitudeSeries {
+	s := make(AttitudeSeries, n+1)
+	s[0] = AttitudeSample{t0, new(Hamilton).Copy(q)}
+	for k := 1; k <= n; k++ {
+		t := t0 + float64(k-1)*dt
+		s[k] = AttitudeSample{t0 + float64(k)*dt, step(f, t, s[k-1].Q, dt)}
+	}
+	return s
+}
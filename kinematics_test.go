@@ -0,0 +1,109 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBodyWorldDerivative(t *testing.T) {
+	q := RotationHamilton(Vec3{1, -2, 2}, 0.7)
+	ω := Vec3{0.3, -1.1, 0.5}
+	if got := BodyRate(q, BodyDerivative(q, ω)); got.Sub(ω).Norm() > delta {
+		t.Errorf("BodyRate(BodyDerivative) = %v, want %v", got, ω)
+	}
+	if got := WorldRate(q, WorldDerivative(q, ω)); got.Sub(ω).Norm() > delta {
+		t.Errorf("WorldRate(WorldDerivative) = %v, want %v", got, ω)
+	}
+	// The world angular velocity is the rotated body angular velocity.
+	if got, want := WorldRate(q, BodyDerivative(q, ω)), q.Rotate(ω); got.Sub(want).Norm() > delta {
+		t.Errorf("WorldRate(BodyDerivative) = %v, want %v", got, want)
+	}
+	// The derivative of a unit is tangent to the sphere.
+	if d := dotHamilton(q, BodyDerivative(q, ω)); notEquals(d, 0) {
+		t.Errorf("derivative is not tangent: %v", d)
+	}
+}
+
+func TestRateBetween(t *testing.T) {
+	p := RotationHamilton(Vec3{0, 1, 1}, 1.3)
+	ω := Vec3{0.4, 0.2, -0.9}
+	dt := 0.5
+	q := ExpStep(p, ω, dt)
+	if got := BodyRateBetween(p, q, dt); got.Sub(ω).Norm() > delta {
+		t.Errorf("BodyRateBetween = %v, want %v", got, ω)
+	}
+	if got, want := WorldRateBetween(p, q, dt), p.Rotate(ω); got.Sub(want).Norm() > delta {
+		t.Errorf("WorldRateBetween = %v, want %v", got, want)
+	}
+	// The sign of q does not matter.
+	if got := BodyRateBetween(p, new(Hamilton).Neg(q), dt); got.Sub(ω).Norm() > delta {
+		t.Errorf("BodyRateBetween(p, -q) = %v, want %v", got, ω)
+	}
+}
+
+func TestExpStep(t *testing.T) {
+	q := RotationHamilton(Vec3{1, 0, 0}, 0.4)
+	ω := Vec3{0, 0, 2}
+	got := ExpStep(q, ω, 0.25)
+	want := new(Hamilton).Mul(q, RotationHamilton(Vec3{0, 0, 1}, 0.5))
+	if !got.ApproxEquals(want) {
+		t.Errorf("ExpStep = %v, want %v", got, want)
+	}
+}
+
+func TestRotationVectorRate(t *testing.T) {
+	// The rotation vector u of p*ExpMap(u) changes at the rate
+	// RightJacobianInv(u)*ω. Compare with a finite difference.
+	u := Vec3{0.8, -0.3, 1.1}
+	v := Vec3{0.2, 0.5, -0.4}
+	h := 1e-6
+	p := ExpMap(u)
+	dq := new(Hamilton).Sub(ExpMap(u.Add(v.Scale(h))), ExpMap(u.Sub(v.Scale(h))))
+	ω := BodyRate(p, dq.Dil(dq, 1/(2*h)))
+	if got := RightJacobianInv(u).MulVec(ω); got.Sub(v).Norm() > 1e-6 {
+		t.Errorf("RightJacobianInv(u)*ω = %v, want %v", got, v)
+	}
+}
+
+// tumblingRate is a time-dependent and attitude-dependent body angular
+// velocity.
+func tumblingRate(t float64, q *Hamilton) Vec3 {
+	g := new(Hamilton).Conj(q).Rotate(Vec3{0, 0, 1})
+	return Vec3{math.Sin(t), math.Cos(2 * t), 0.5}.Add(g.Scale(0.8))
+}
+
+func TestSteppers(t *testing.T) {
+	q0 := RotationHamilton(Vec3{1, 1, 0}, 0.3)
+	ref := IntegrateAttitude(RKMK4Step, tumblingRate, q0, 0, 1.0/4096, 4096)
+	want := ref[len(ref)-1].Q
+	tests := []struct {
+		name  string
+		step  Stepper
+		order float64
+	}{
+		{"RK4Step", RK4Step, 4},
+		{"CG3Step", CG3Step, 3},
+		{"RKMK4Step", RKMK4Step, 4},
+	}
+	for _, test := range tests {
+		var errs [2]float64
+		for k, n := range []int{32, 64} {
+			s := IntegrateAttitude(test.step, tumblingRate, q0, 0, 1/float64(n), n)
+			for _, a := range s {
+				if notEquals(a.Q.Quad(), 1) {
+					t.Fatalf("%s: attitude %v is not a unit", test.name, a.Q)
+				}
+			}
+			if got := s[len(s)-1].Time; notEquals(got, 1) {
+				t.Errorf("%s: final time = %v, want 1", test.name, got)
+			}
+			errs[k] = angleBetweenRotations(s[len(s)-1].Q, want)
+		}
+		if order := math.Log2(errs[0] / errs[1]); math.Abs(order-test.order) > 0.4 {
+			t.Errorf("%s: observed order %v, want %v (errors %v)", test.name, order, test.order, errs)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// The functions in this file treat the unit Hamilton values as the Lie group
+// SU(2), which covers the rotation group SO(3) twice, with the Lie algebra
+// identified with the rotation vectors: a vector ω with length θ represents
+// the rotation by the angle θ about ω, and the Lie bracket is the cross
+// product. The perturbations are on the right, as in q*ExpMap(δ), with δ in
+// the body frame. The functions use Taylor expansions near zero rotation, and
+// forms that stay accurate up to the rotation by π.
+
+// smallAngle is the angle below which the functions use Taylor expansions.
+const smallAngle = 1e-2
+
+// ExpMap returns the unit Hamilton value Exp(ω/2) of the rotation vector ω.
+func ExpMap(ω Vec3) *Hamilton {
+	θ := ω.Norm()
+	θ2 := θ * θ
+	s := 0.5 - θ2/48 + θ2*θ2/3840
+	if θ >= smallAngle {
+		s = math.Sin(θ/2) / θ
+	}
+	return NewHamilton(math.Cos(θ/2), s*ω[0], s*ω[1], s*ω[2])
+}
+
+// LogMap returns the rotation vector of the unit Hamilton value q, whose
+// length is the angle of the rotation in the interval [0, π]. ExpMap(LogMap(q))
+// is either q or -q. Since q and -q are the same rotation, LogMap(q) equals
+// LogMap(-q) if the real part of q is not zero. For a rotation by π, the two
+// results are opposite vectors, as LogMap(j) is πj and LogMap(-j) is -πj.
+func LogMap(q *Hamilton) Vec3 {
+	a, _, _, _ := q.Cartesian()
+	v := q.Vec()
+	if a < 0 {
+		a, v = -a, v.Scale(-1)
+	}
+	n := v.Norm()
+	if n < smallAngle*a {
+		// With t = n/a, 2*atan(t)/n = (2/a)(1 - t²/3 + t⁴/5 - t⁶/7).
+		t2 := n * n / (a * a)
+		return v.Scale(2 / a * (1 - t2/3 + t2*t2/5 - t2*t2*t2/7))
+	}
+	return v.Scale(2 * math.Atan2(n, a) / n)
+}
+
+// Ad returns the adjoint action of the unit Hamilton value q on the rotation
+// vectors, which is the rotation matrix of q: Ad(q)*v equals q.Rotate(v), and
+// q*ExpMap(v)*Conj(q) equals ExpMap(Ad(q)*v).
+func Ad(q *Hamilton) Mat3 {
+	return q.Matrix3()
+}
+
+// jacobianCoefficients returns the coefficients (1 - cos θ)/θ², (θ - sin θ)/θ³,
+// and 1/θ² - cot(θ/2)/(2θ) of the Jacobians of the rotation vector ω.
+func jacobianCoefficients(ω Vec3) (a, b, c float64) {
+	θ := ω.Norm()
+	θ2 := θ * θ
+	if θ < smallAngle {
+		return 0.5 - θ2/24 + θ2*θ2/720,
+			1.0/6 - θ2/120 + θ2*θ2/5040,
+			1.0/12 + θ2/720 + θ2*θ2/30240
+	}
+	return (1 - math.Cos(θ)) / θ2,
+		(θ - math.Sin(θ)) / (θ2 * θ),
+		1/θ2 - 1/(2*θ*math.Tan(θ/2))
+}
+
+// LeftJacobian returns the left Jacobian of the rotation vector ω, which is
+// I + a[ω×] + b[ω×]², with the coefficients a = (1 - cos θ)/θ² and
+// b = (θ - sin θ)/θ³, and θ = |ω|. For a small δ,
+// ExpMap(ω + δ) ≈ ExpMap(LeftJacobian(ω)*δ)*ExpMap(ω).
+func LeftJacobian(ω Vec3) Mat3 {
+	a, b, _ := jacobianCoefficients(ω)
+	k := Skew(ω)
+	return Identity3().Add(k.Scale(a)).Add(k.Mul(k).Scale(b))
+}
+
+// RightJacobian returns the right Jacobian of the rotation vector ω, which is
+// LeftJacobian(-ω). For a small δ,
+// ExpMap(ω + δ) ≈ ExpMap(ω)*ExpMap(RightJacobian(ω)*δ).
+func RightJacobian(ω Vec3) Mat3 {
+	return LeftJacobian(ω.Scale(-1))
+}
+
+// LeftJacobianInv returns the inverse of LeftJacobian(ω), which is
+// I - ½[ω×] + c[ω×]², with c = 1/θ² - cot(θ/2)/(2θ) and θ = |ω|. It is singular
+// only at θ = 2π.
+func LeftJacobianInv(ω Vec3) Mat3 {
+	_, _, c := jacobianCoefficients(ω)
+	k := Skew(ω)
+	return Identity3().Sub(k.Scale(0.5)).Add(k.Mul(k).Scale(c))
+}
+
+// RightJacobianInv returns the inverse of RightJacobian(ω), which is
+// LeftJacobianInv(-ω). If q(t) = p*ExpMap(u(t)) has the body angular velocity
+// ω, then du/dt = RightJacobianInv(u)*ω.
+func RightJacobianInv(ω Vec3) Mat3 {
+	return LeftJacobianInv(ω.Scale(-1))
+}
+
+// BoxPlus returns the unit Hamilton value q*ExpMap(δ), which is q perturbed by
+// the body rotation vector δ.
+func BoxPlus(q *Hamilton, δ Vec3) *Hamilton {
+	p := new(Hamilton).Mul(q, ExpMap(δ))
+	return p.Normalize(p)
+}
+
+// BoxMinus returns the body rotation vector LogMap(Conj(q)*p) from the unit
+// Hamilton value q to p, so that BoxPlus(q, BoxMinus(p, q)) is p or -p.
+func BoxMinus(p, q *Hamilton) Vec3 {
+	return LogMap(new(Hamilton).Mul(new(Hamilton).Conj(q), p))
+}
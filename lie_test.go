@@ -0,0 +1,133 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+// lieTestVectors are rotation vectors with angles near zero, on both sides of
+// smallAngle, generic, and near π.
+var lieTestVectors = []Vec3{
+	{},
+	{1e-12, -2e-12, 0},
+	{3e-5, 1e-5, -2e-5},
+	Vec3{1, -2, 2}.Unit().Scale(smallAngle * (1 - 1e-9)),
+	Vec3{1, -2, 2}.Unit().Scale(smallAngle * (1 + 1e-9)),
+	{0.3, -0.5, 0.9},
+	Vec3{-1, 1, 3}.Unit().Scale(2.5),
+	Vec3{2, 1, 0}.Unit().Scale(math.Pi - 1e-7),
+}
+
+// closeMat3 reports whether m and n agree within tol.
+func closeMat3(m, n Mat3, tol float64) bool {
+	for i := range m {
+		for j := range m[i] {
+			if math.Abs(m[i][j]-n[i][j]) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestExpMapLogMap(t *testing.T) {
+	for _, ω := range lieTestVectors {
+		q := ExpMap(ω)
+		θ := ω.Norm()
+		if θ > 0 {
+			if want := RotationHamilton(ω, θ); !q.ApproxEquals(want) {
+				t.Errorf("ExpMap(%v) = %v, want %v", ω, q, want)
+			}
+		}
+		if notEquals(q.Quad(), 1) {
+			t.Errorf("ExpMap(%v) = %v is not a unit", ω, q)
+		}
+		if got := LogMap(q); got.Sub(ω).Norm() > 1e-12*math.Max(1, θ) {
+			t.Errorf("LogMap(ExpMap(%v)) = %v", ω, got)
+		}
+		if got := LogMap(new(Hamilton).Neg(q)); got.Sub(ω).Norm() > 1e-12*math.Max(1, θ) {
+			t.Errorf("LogMap(-ExpMap(%v)) = %v", ω, got)
+		}
+	}
+	// Tiny rotations keep their full relative precision.
+	ω := Vec3{1e-200, 0, 0}
+	if got := LogMap(ExpMap(ω)); got != ω {
+		t.Errorf("LogMap(ExpMap(%v)) = %v", ω, got)
+	}
+	// The rotation by π about any axis.
+	if got := LogMap(NewHamilton(0, 0, 1, 0)); got.Sub(Vec3{0, math.Pi, 0}).Norm() > delta {
+		t.Errorf("LogMap(j) = %v", got)
+	}
+}
+
+func TestAd(t *testing.T) {
+	q := RotationHamilton(Vec3{1, 2, 3}, 2.1)
+	v := Vec3{-0.4, 0.7, 0.2}
+	a := Ad(q)
+	if got, want := a.MulVec(v), q.Rotate(v); got.Sub(want).Norm() > delta {
+		t.Errorf("Ad(q)*v = %v, want %v", got, want)
+	}
+	if !closeMat3(a.Mul(a.Transpose()), Identity3(), delta) || notEquals(a.Det(), 1) {
+		t.Errorf("Ad(q) = %v is not a rotation", a)
+	}
+	p := new(Hamilton).Mul(q, ExpMap(v))
+	p.Mul(p, new(Hamilton).Conj(q))
+	if want := ExpMap(a.MulVec(v)); !p.ApproxEquals(want) {
+		t.Errorf("q*ExpMap(v)*Conj(q) = %v, want %v", p, want)
+	}
+}
+
+func TestJacobians(t *testing.T) {
+	for _, ω := range lieTestVectors {
+		jl, jr := LeftJacobian(ω), RightJacobian(ω)
+		if !closeMat3(jl.Mul(LeftJacobianInv(ω)), Identity3(), 1e-9) {
+			t.Errorf("LeftJacobian(%v)*LeftJacobianInv(%v) is not the identity", ω, ω)
+		}
+		if !closeMat3(jr.Mul(RightJacobianInv(ω)), Identity3(), 1e-9) {
+			t.Errorf("RightJacobian(%v)*RightJacobianInv(%v) is not the identity", ω, ω)
+		}
+		// The left and right Jacobians are related by the adjoint action.
+		if !closeMat3(jl, Ad(ExpMap(ω)).Mul(jr), 1e-12) {
+			t.Errorf("LeftJacobian(%v) != Ad(ExpMap(%v))*RightJacobian(%v)", ω, ω, ω)
+		}
+		// Compare with finite differences of ExpMap.
+		const h = 1e-6
+		for k := 0; k < 3; k++ {
+			var δ Vec3
+			δ[k] = h
+			dr := BoxMinus(ExpMap(ω.Add(δ)), ExpMap(ω.Sub(δ))).Scale(1 / (2 * h))
+			pl := new(Hamilton).Mul(ExpMap(ω.Add(δ)), new(Hamilton).Conj(ExpMap(ω.Sub(δ))))
+			dl := LogMap(pl).Scale(1 / (2 * h))
+			for i := 0; i < 3; i++ {
+				if math.Abs(dr[i]-jr[i][k]) > 1e-6 {
+					t.Errorf("RightJacobian(%v)[%d][%d] = %v, want %v", ω, i, k, jr[i][k], dr[i])
+				}
+				if math.Abs(dl[i]-jl[i][k]) > 1e-6 {
+					t.Errorf("LeftJacobian(%v)[%d][%d] = %v, want %v", ω, i, k, jl[i][k], dl[i])
+				}
+			}
+		}
+	}
+	// The coefficients are continuous across smallAngle.
+	a0, b0, c0 := jacobianCoefficients(lieTestVectors[3])
+	a1, b1, c1 := jacobianCoefficients(lieTestVectors[4])
+	if math.Abs(a0-a1) > 1e-12 || math.Abs(b0-b1) > 1e-12 || math.Abs(c0-c1) > 1e-10 {
+		t.Errorf("coefficients jump at smallAngle: %v %v %v, %v %v %v", a0, b0, c0, a1, b1, c1)
+	}
+}
+
+func TestBoxPlusBoxMinus(t *testing.T) {
+	q := RotationHamilton(Vec3{0, 1, -1}, 0.9)
+	for _, δ := range lieTestVectors {
+		p := BoxPlus(q, δ)
+		if got := BoxMinus(p, q); got.Sub(δ).Norm() > 1e-12*math.Max(1, δ.Norm()) {
+			t.Errorf("BoxMinus(BoxPlus(q, %v), q) = %v", δ, got)
+		}
+		if got := BoxPlus(q, BoxMinus(p, q)); !got.ApproxEquals(p) && !got.ApproxEquals(new(Hamilton).Neg(p)) {
+			t.Errorf("BoxPlus(q, BoxMinus(p, q)) = %v, want ±%v", got, p)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// symEigen returns the eigenvalues of a real symmetric matrix a in decreasing
+// order, along with a matrix whose columns are the corresponding unit
+// eigenvectors. It uses cyclic Jacobi rotations and leaves a unchanged.
+func symEigen(a [][]float64) (vals []float64, vecs [][]float64) {
+	n := len(a)
+	m := make([][]float64, n)
+	v := make([][]float64, n)
+	for i := range m {
+		m[i] = append([]float64(nil), a[i]...)
+		v[i] = make([]float64, n)
+		v[i][i] = 1
+	}
+	for sweep := 0; sweep < 100; sweep++ {
+		var off float64
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += m[p][q] * m[p][q]
+			}
+		}
+		if off < 1e-30 {
+			break
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if m[p][q] == 0 {
+					continue
+				}
+				θ := (m[q][q] - m[p][p]) / (2 * m[p][q])
+				t := 1 / (math.Abs(θ) + math.Sqrt(θ*θ+1))
+				if θ < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < n; k++ {
+					mkp, mkq := m[k][p], m[k][q]
+					m[k][p] = c*mkp - s*mkq
+					m[k][q] = s*mkp + c*mkq
+				}
+				for k := 0; k < n; k++ {
+					mpk, mqk := m[p][k], m[q][k]
+					m[p][k] = c*mpk - s*mqk
+					m[q][k] = s*mpk + c*mqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p] = c*vkp - s*vkq
+					v[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return m[order[i]][order[i]] > m[order[j]][order[j]]
+	})
+	vals = make([]float64, n)
+	vecs = make([][]float64, n)
+	for i := range vecs {
+		vecs[i] = make([]float64, n)
+	}
+	for j, k := range order {
+		vals[j] = m[k][k]
+		for i := 0; i < n; i++ {
+			vecs[i][j] = v[i][k]
+		}
+	}
+	return
+}
+
+// solveLinear returns the solution x of the square linear system a*x = b,
+// using Gaussian elimination with partial pivoting. It leaves a and b
+// unchanged, and returns false if a is singular.
+func solveLinear(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(a)
+	m := make([][]float64, n)
+	var scale float64
+	for i := range m {
+		m[i] = append(append([]float64(nil), a[i]...), b[i])
+		for _, v := range a[i] {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(m[i][k]) > math.Abs(m[p][k]) {
+				p = i
+			}
+		}
+		if math.Abs(m[p][k]) <= 1e-12*scale {
+			return nil, false
+		}
+		m[k], m[p] = m[p], m[k]
+		for i := k + 1; i < n; i++ {
+			f := m[i][k] / m[k][k]
+			for j := k; j <= n; j++ {
+				m[i][j] -= f * m[k][j]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		s := m[i][n]
+		for j := i + 1; j < n; j++ {
+			s -= m[i][j] * x[j]
+		}
+		x[i] = s / m[i][i]
+	}
+	return x, true
+}
+
+// complexDet returns the determinant of a square complex matrix a, using
+// Gaussian elimination with partial pivoting. It leaves a unchanged.
+func complexDet(a [][]complex128) complex128 {
+	n := len(a)
+	m := make([][]complex128, n)
+	for i := range m {
+		m[i] = append([]complex128(nil), a[i]...)
+	}
+	det := complex(1, 0)
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if cmplx.Abs(m[i][k]) > cmplx.Abs(m[p][k]) {
+				p = i
+			}
+		}
+		if m[p][k] == 0 {
+			return 0
+		}
+		if p != k {
+			m[k], m[p] = m[p], m[k]
+			det = -det
+		}
+		det *= m[k][k]
+		for i := k + 1; i < n; i++ {
+			f := m[i][k] / m[k][k]
+			for j := k; j < n; j++ {
+				m[i][j] -= f * m[k][j]
+			}
+		}
+	}
+	return det
+}
+
+// matMul returns the product of the real matrices a and b.
+func matMul(a, b [][]float64) [][]float64 {
+	c := make([][]float64, len(a))
+	for i := range a {
+		c[i] = make([]float64, len(b[0]))
+		for k := range b {
+			if a[i][k] == 0 {
+				continue
+			}
+			for j := range b[k] {
+				c[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return c
+}
+
+// matTranspose returns the transpose of the real matrix a.
+func matTranspose(a [][]float64) [][]float64 {
+	t := make([][]float64, len(a[0]))
+	for j := range t {
+		t[j] = make([]float64, len(a))
+		for i := range a {
+			t[j][i] = a[i][j]
+		}
+	}
+	return t
+}
+
+// matInverse returns the inverse of the square real matrix a. If a is
+// singular, then matInverse panics.
+func matInverse(a [][]float64) [][]float64 {
+	n := len(a)
+	inv := make([][]float64, n)
+	for i := range inv {
+		inv[i] = make([]float64, n)
+	}
+	e := make([]float64, n)
+	for j := 0; j < n; j++ {
+		e[j] = 1
+		x, ok := solveLinear(a, e)
+		if !ok {
+			panic("inverse of singular matrix")
+		}
+		for i := range x {
+			inv[i][j] = x[i]
+		}
+		e[j] = 0
+	}
+	return inv
+}
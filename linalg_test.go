@@ -0,0 +1,75 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "testing"
+
+func TestSymEigen(t *testing.T) {
+	a := [][]float64{
+		{2, 1, 0},
+		{1, 2, 0},
+		{0, 0, 5},
+	}
+	vals, vecs := symEigen(a)
+	want := []float64{5, 3, 1}
+	for j, λ := range vals {
+		if notEquals(λ, want[j]) {
+			t.Errorf("eigenvalue %d = %v, want %v", j, λ, want[j])
+		}
+		for i := range a {
+			var av float64
+			for k := range a {
+				av += a[i][k] * vecs[k][j]
+			}
+			if notEquals(av, λ*vecs[i][j]) {
+				t.Errorf("column %d is not an eigenvector", j)
+				break
+			}
+		}
+	}
+}
+
+func TestSolveLinear(t *testing.T) {
+	a := [][]float64{
+		{0, 2, 1},
+		{1, 1, 0},
+		{3, 0, 1},
+	}
+	b := []float64{5, 3, 6}
+	x, ok := solveLinear(a, b)
+	if !ok {
+		t.Fatal("solveLinear reported a singular matrix")
+	}
+	for i, want := range []float64{1.4, 1.6, 1.8} {
+		if notEquals(x[i], want) {
+			t.Errorf("x[%d] = %v, want %v", i, x[i], want)
+		}
+	}
+	if _, ok := solveLinear([][]float64{{1, 2}, {2, 4}}, []float64{1, 2}); ok {
+		t.Error("solveLinear did not report a singular matrix")
+	}
+}
+
+func TestMatInverse(t *testing.T) {
+	a := [][]float64{
+		{4, 1, 0},
+		{1, 3, 1},
+		{0, 1, 2},
+	}
+	p := matMul(a, matInverse(a))
+	for i := range p {
+		for j := range p[i] {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if notEquals(p[i][j], want) {
+				t.Errorf("(a*Inv(a))[%d][%d] = %v, want %v", i, j, p[i][j], want)
+			}
+		}
+	}
+	if at := matTranspose(a); at[0][1] != a[1][0] || at[2][1] != a[1][2] {
+		t.Errorf("matTranspose(%v) = %v", a, at)
+	}
+}
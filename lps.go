@@ -0,0 +1,267 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+)
+
+// An LPSGraph represents the Lubotzky-Phillips-Sarnak Ramanujan graph X^{p,q}
+// as an adjacency list. Each vertex has p+1 neighbors, one for each generator
+// returned by LPSGenerators, listed in the same order.
+type LPSGraph struct {
+	P, Q      int
+	Bipartite bool
+	Adj       [][]int
+}
+
+// isPrime returns true if n is a prime number.
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// powMod returns a raised to the nth power modulo m.
+func powMod(a, n, m int) int {
+	r, a := 1, ((a%m)+m)%m
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r = (r * a) % m
+		}
+		a = (a * a) % m
+	}
+	return r
+}
+
+// LPSGenerators returns the p+1 integer Hamilton quaternions a + bi + cj + dk
+// with quadrance p, a odd and positive, and b, c, d even. If p is not a prime
+// congruent to 1 mod 4, then LPSGenerators panics.
+func LPSGenerators(p int) []*Hamilton {
+	if !isPrime(p) || p%4 != 1 {
+		panic("p is not a prime congruent to 1 mod 4")
+	}
+	m := int(math.Sqrt(float64(p)))
+	var gens []*Hamilton
+	for a := 1; a <= m; a += 2 {
+		for b := -m; b <= m; b++ {
+			for c := -m; c <= m; c++ {
+				for d := -m; d <= m; d++ {
+					if b%2 != 0 || c%2 != 0 || d%2 != 0 {
+						continue
+					}
+					if a*a+b*b+c*c+d*d == p {
+						gens = append(gens, NewHamilton(
+							float64(a), float64(b), float64(c), float64(d),
+						))
+					}
+				}
+			}
+		}
+	}
+	return gens
+}
+
+// lpsMatrix is an element of PGL(2, Z/qZ), stored as {a, b, c, d} for the
+// matrix with rows (a, b) and (c, d).
+type lpsMatrix [4]int
+
+// normalize scales m so that its first non-zero entry is 1.
+func (m lpsMatrix) normalize(q int) lpsMatrix {
+	for _, v := range m {
+		if v != 0 {
+			inv := powMod(v, q-2, q)
+			for i := range m {
+				m[i] = (m[i] * inv) % q
+			}
+			break
+		}
+	}
+	return m
+}
+
+// mul returns the product of m and n modulo q.
+func (m lpsMatrix) mul(n lpsMatrix, q int) lpsMatrix {
+	return lpsMatrix{
+		(m[0]*n[0] + m[1]*n[2]) % q,
+		(m[0]*n[1] + m[1]*n[3]) % q,
+		(m[2]*n[0] + m[3]*n[2]) % q,
+		(m[2]*n[1] + m[3]*n[3]) % q,
+	}
+}
+
+// NewLPSGraph returns the Cayley graph X^{p,q} of PSL(2, Z/qZ) (if p is a
+// quadratic residue mod q) or PGL(2, Z/qZ) (otherwise), with generators given
+// by the images of LPSGenerators(p). If p and q are not distinct primes
+// congruent to 1 mod 4, then NewLPSGraph panics.
+func NewLPSGraph(p, q int) *LPSGraph {
+	if !isPrime(q) || q%4 != 1 {
+		panic("q is not a prime congruent to 1 mod 4")
+	}
+	if p == q {
+		panic("p and q are equal")
+	}
+	gens := LPSGenerators(p)
+	x := 1
+	for (x*x)%q != q-1 {
+		x++
+	}
+	s := make([]lpsMatrix, len(gens))
+	for n, h := range gens {
+		a, b, c, d := h.Cartesian()
+		u := [4]int{int(a), int(b), int(c), int(d)}
+		for i := range u {
+			u[i] = ((u[i] % q) + q) % q
+		}
+		s[n] = lpsMatrix{
+			(u[0] + x*u[1]) % q,
+			(u[2] + x*u[3]) % q,
+			(q - u[2] + x*u[3]) % q,
+			(u[0] + (q-x)*u[1]) % q,
+		}.normalize(q)
+	}
+	g := &LPSGraph{
+		P:         p,
+		Q:         q,
+		Bipartite: powMod(p, (q-1)/2, q) != 1,
+	}
+	index := map[lpsMatrix]int{{1, 0, 0, 1}: 0}
+	queue := []lpsMatrix{{1, 0, 0, 1}}
+	for v := 0; v < len(queue); v++ {
+		adj := make([]int, len(s))
+		for n, t := range s {
+			w := queue[v].mul(t, q).normalize(q)
+			i, ok := index[w]
+			if !ok {
+				i = len(queue)
+				index[w] = i
+				queue = append(queue, w)
+			}
+			adj[n] = i
+		}
+		g.Adj = append(g.Adj, adj)
+	}
+	return g
+}
+
+// Order returns the number of vertices of g.
+func (g *LPSGraph) Order() int {
+	return len(g.Adj)
+}
+
+// WriteEdgeList writes each edge of g to w as a line "u v", with u < v.
+func (g *LPSGraph) WriteEdgeList(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	for u, adj := range g.Adj {
+		for _, v := range adj {
+			if u < v {
+				fmt.Fprintf(b, "%d %d\n", u, v)
+			}
+		}
+	}
+	return b.Flush()
+}
+
+// WriteDOT writes g to w in the Graphviz DOT language.
+func (g *LPSGraph) WriteDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "graph X_%d_%d {\n", g.P, g.Q)
+	for u, adj := range g.Adj {
+		for _, v := range adj {
+			if u < v {
+				fmt.Fprintf(b, "\t%d -- %d;\n", u, v)
+			}
+		}
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// SecondEigenvalue returns an estimate of the largest absolute value of the
+// non-trivial eigenvalues of the adjacency matrix of g. The trivial
+// eigenvalues are p+1 and, if g is bipartite, -(p+1). The estimate is found by
+// power iteration, and it approaches the true value from below.
+func (g *LPSGraph) SecondEigenvalue() float64 {
+	n := g.Order()
+	// The sign vector separates the two sides of a bipartite graph.
+	sign := make([]float64, n)
+	if g.Bipartite {
+		for u := range sign {
+			sign[u] = math.NaN()
+		}
+		sign[0] = 1
+		queue := []int{0}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range g.Adj[u] {
+				if math.IsNaN(sign[v]) {
+					sign[v] = -sign[u]
+					queue = append(queue, v)
+				}
+			}
+		}
+	}
+	project := func(x []float64) {
+		var sum, alt float64
+		for u, v := range x {
+			sum += v
+			alt += v * sign[u]
+		}
+		for u := range x {
+			x[u] -= sum / float64(n)
+			if g.Bipartite {
+				x[u] -= alt * sign[u] / float64(n)
+			}
+		}
+	}
+	normalize := func(x []float64) float64 {
+		var s float64
+		for _, v := range x {
+			s += v * v
+		}
+		s = math.Sqrt(s)
+		for u := range x {
+			x[u] /= s
+		}
+		return s
+	}
+	rnd := rand.New(rand.NewSource(int64(n)))
+	x := make([]float64, n)
+	for u := range x {
+		x[u] = rnd.Float64() - 0.5
+	}
+	project(x)
+	normalize(x)
+	y := make([]float64, n)
+	var λ float64
+	for k := 0; k < 500; k++ {
+		for u, adj := range g.Adj {
+			y[u] = 0
+			for _, v := range adj {
+				y[u] += x[v]
+			}
+		}
+		project(y)
+		λ = normalize(y)
+		x, y = y, x
+	}
+	return λ
+}
+
+// IsRamanujan returns true if the estimate from SecondEigenvalue does not
+// exceed the Ramanujan bound 2√p.
+func (g *LPSGraph) IsRamanujan() bool {
+	return g.SecondEigenvalue() <= 2*math.Sqrt(float64(g.P))+delta
+}
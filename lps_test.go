@@ -0,0 +1,95 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleLPSGenerators() {
+	for _, h := range LPSGenerators(5) {
+		fmt.Println(h)
+	}
+	// Output:
+	// (1-2i+0j+0k)
+	// (1+0i-2j+0k)
+	// (1+0i+0j-2k)
+	// (1+0i+0j+2k)
+	// (1+0i+2j+0k)
+	// (1+2i+0j+0k)
+}
+
+func TestLPSGenerators(t *testing.T) {
+	for _, p := range []int{5, 13, 17, 29} {
+		gens := LPSGenerators(p)
+		if len(gens) != p+1 {
+			t.Errorf("LPSGenerators(%d) has %d elements, want %d", p, len(gens), p+1)
+		}
+		for _, h := range gens {
+			if notEquals(h.Quad(), float64(p)) {
+				t.Errorf("Quad(%v) = %v, want %d", h, h.Quad(), p)
+			}
+		}
+	}
+}
+
+func TestNewLPSGraph(t *testing.T) {
+	var tests = []struct {
+		p, q      int
+		order     int
+		bipartite bool
+	}{
+		{5, 13, 13 * (13*13 - 1), true},
+		{13, 17, 17 * (17*17 - 1) / 2, false},
+	}
+	for _, tt := range tests {
+		g := NewLPSGraph(tt.p, tt.q)
+		if g.Order() != tt.order {
+			t.Errorf("order of X^{%d,%d} = %d, want %d", tt.p, tt.q, g.Order(), tt.order)
+		}
+		if g.Bipartite != tt.bipartite {
+			t.Errorf("X^{%d,%d} bipartite = %v, want %v", tt.p, tt.q, g.Bipartite, tt.bipartite)
+		}
+		for u, adj := range g.Adj {
+			if len(adj) != tt.p+1 {
+				t.Fatalf("vertex %d has degree %d, want %d", u, len(adj), tt.p+1)
+			}
+			for _, v := range adj {
+				found := false
+				for _, w := range g.Adj[v] {
+					if w == u {
+						found = true
+					}
+				}
+				if !found {
+					t.Fatalf("edge %d-%d is not symmetric", u, v)
+				}
+			}
+		}
+		if !g.IsRamanujan() {
+			t.Errorf("X^{%d,%d} has second eigenvalue %v", tt.p, tt.q, g.SecondEigenvalue())
+		}
+	}
+}
+
+func TestLPSGraphWrite(t *testing.T) {
+	g := NewLPSGraph(5, 13)
+	var b bytes.Buffer
+	if err := g.WriteEdgeList(&b); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(b.String(), "\n"); n != g.Order()*6/2 {
+		t.Errorf("edge list has %d edges, want %d", n, g.Order()*6/2)
+	}
+	b.Reset()
+	if err := g.WriteDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(b.String(), "graph X_5_13 {") {
+		t.Errorf("unexpected DOT header: %q", strings.SplitN(b.String(), "\n", 2)[0])
+	}
+}
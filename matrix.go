@@ -0,0 +1,338 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// A HamiltonVector represents a column vector with Hamilton entries.
+type HamiltonVector []*Hamilton
+
+// A HamiltonMatrix represents a matrix with Hamilton entries, stored as a
+// slice of rows. Matrices act on vectors from the left, so that the entries
+// of the matrix multiply the entries of the vector on the left.
+type HamiltonMatrix [][]*Hamilton
+
+// NewHamiltonVector returns a zero HamiltonVector of length n.
+func NewHamiltonVector(n int) HamiltonVector {
+	v := make(HamiltonVector, n)
+	for i := range v {
+		v[i] = new(Hamilton)
+	}
+	return v
+}
+
+// Copy returns a copy of v.
+func (v HamiltonVector) Copy() HamiltonVector {
+	w := make(HamiltonVector, len(v))
+	for i, a := range v {
+		w[i] = new(Hamilton).Copy(a)
+	}
+	return w
+}
+
+// ScaleLeft returns the vector with entries a*v[i].
+func (v HamiltonVector) ScaleLeft(a *Hamilton) HamiltonVector {
+	w := make(HamiltonVector, len(v))
+	for i, b := range v {
+		w[i] = new(Hamilton).Mul(a, b)
+	}
+	return w
+}
+
+// ScaleRight returns the vector with entries v[i]*a.
+func (v HamiltonVector) ScaleRight(a *Hamilton) HamiltonVector {
+	w := make(HamiltonVector, len(v))
+	for i, b := range v {
+		w[i] = new(Hamilton).Mul(b, a)
+	}
+	return w
+}
+
+// Dot returns the inner product of v and w, which is the sum of the products
+// Conj(v[i])*w[i]. If v and w have different lengths, then Dot panics.
+func (v HamiltonVector) Dot(w HamiltonVector) *Hamilton {
+	if len(v) != len(w) {
+		panic("dimension mismatch")
+	}
+	s := new(Hamilton)
+	for i := range v {
+		s.Add(s, new(Hamilton).Mul(new(Hamilton).Conj(v[i]), w[i]))
+	}
+	return s
+}
+
+// Norm returns the Euclidean length of v.
+func (v HamiltonVector) Norm() float64 {
+	var s float64
+	for _, a := range v {
+		s += a.Quad()
+	}
+	return math.Sqrt(s)
+}
+
+// NewHamiltonMatrix returns a zero HamiltonMatrix with r rows and c columns.
+func NewHamiltonMatrix(r, c int) HamiltonMatrix {
+	m := make(HamiltonMatrix, r)
+	for i := range m {
+		m[i] = NewHamiltonVector(c)
+	}
+	return m
+}
+
+// IdentityHamiltonMatrix returns the n×n identity HamiltonMatrix.
+func IdentityHamiltonMatrix(n int) HamiltonMatrix {
+	m := NewHamiltonMatrix(n, n)
+	for i := range m {
+		m[i][i] = NewHamilton(1, 0, 0, 0)
+	}
+	return m
+}
+
+// Dims returns the number of rows and columns of m.
+func (m HamiltonMatrix) Dims() (r, c int) {
+	if len(m) == 0 {
+		return 0, 0
+	}
+	return len(m), len(m[0])
+}
+
+// Copy returns a copy of m.
+func (m HamiltonMatrix) Copy() HamiltonMatrix {
+	n := make(HamiltonMatrix, len(m))
+	for i, row := range m {
+		n[i] = HamiltonVector(row).Copy()
+	}
+	return n
+}
+
+// ScaleLeft returns the matrix with entries a*m[i][j].
+func (m HamiltonMatrix) ScaleLeft(a *Hamilton) HamiltonMatrix {
+	n := make(HamiltonMatrix, len(m))
+	for i, row := range m {
+		n[i] = HamiltonVector(row).ScaleLeft(a)
+	}
+	return n
+}
+
+// ScaleRight returns the matrix with entries m[i][j]*a.
+func (m HamiltonMatrix) ScaleRight(a *Hamilton) HamiltonMatrix {
+	n := make(HamiltonMatrix, len(m))
+	for i, row := range m {
+		n[i] = HamiltonVector(row).ScaleRight(a)
+	}
+	return n
+}
+
+// ConjTranspose returns the conjugate transpose of m.
+func (m HamiltonMatrix) ConjTranspose() HamiltonMatrix {
+	r, c := m.Dims()
+	n := NewHamiltonMatrix(c, r)
+	for i := range m {
+		for j := range m[i] {
+			n[j][i].Conj(m[i][j])
+		}
+	}
+	return n
+}
+
+// MulVec returns the product of m and v. If the number of columns of m differs
+// from the length of v, then MulVec panics.
+func (m HamiltonMatrix) MulVec(v HamiltonVector) HamiltonVector {
+	r, c := m.Dims()
+	if c != len(v) {
+		panic("dimension mismatch")
+	}
+	w := NewHamiltonVector(r)
+	for i := range m {
+		for j := range v {
+			w[i].Add(w[i], new(Hamilton).Mul(m[i][j], v[j]))
+		}
+	}
+	return w
+}
+
+// Mul returns the product of m and n. If the number of columns of m differs
+// from the number of rows of n, then Mul panics.
+func (m HamiltonMatrix) Mul(n HamiltonMatrix) HamiltonMatrix {
+	r, c := m.Dims()
+	s, t := n.Dims()
+	if c != s {
+		panic("dimension mismatch")
+	}
+	p := NewHamiltonMatrix(r, t)
+	for i := 0; i < r; i++ {
+		for j := 0; j < t; j++ {
+			for k := 0; k < c; k++ {
+				p[i][j].Add(p[i][j], new(Hamilton).Mul(m[i][k], n[k][j]))
+			}
+		}
+	}
+	return p
+}
+
+// isSquare panics if m is not square, and returns its size otherwise.
+func (m HamiltonMatrix) isSquare() int {
+	r, c := m.Dims()
+	if r != c {
+		panic("matrix is not square")
+	}
+	return r
+}
+
+// LU returns the LU decomposition of the square matrix m with partial
+// pivoting: row perm[i] of m is row i of l*u, with l unit lower triangular and
+// u upper triangular. If m is not square or is singular, then LU panics.
+func (m HamiltonMatrix) LU() (l, u HamiltonMatrix, perm []int) {
+	n := m.isSquare()
+	u = m.Copy()
+	l = IdentityHamiltonMatrix(n)
+	perm = make([]int, n)
+	var scale float64
+	for i := range perm {
+		perm[i] = i
+		for _, z := range m[i] {
+			scale = math.Max(scale, z.Quad())
+		}
+	}
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if u[i][k].Quad() > u[p][k].Quad() {
+				p = i
+			}
+		}
+		if u[p][k].Quad() <= 1e-24*scale {
+			panic("matrix is singular")
+		}
+		u[k], u[p] = u[p], u[k]
+		perm[k], perm[p] = perm[p], perm[k]
+		for j := 0; j < k; j++ {
+			l[k][j], l[p][j] = l[p][j], l[k][j]
+		}
+		inv := new(Hamilton).Inv(u[k][k])
+		for i := k + 1; i < n; i++ {
+			f := new(Hamilton).Mul(u[i][k], inv)
+			l[i][k] = f
+			for j := k; j < n; j++ {
+				u[i][j].Sub(u[i][j], new(Hamilton).Mul(f, u[k][j]))
+			}
+			u[i][k] = new(Hamilton)
+		}
+	}
+	return l, u, perm
+}
+
+// Solve returns the solution x of the linear system m*x = b, using the LU
+// decomposition of m. If m is not square or is singular, then Solve panics.
+func (m HamiltonMatrix) Solve(b HamiltonVector) HamiltonVector {
+	l, u, perm := m.LU()
+	n := len(perm)
+	if len(b) != n {
+		panic("dimension mismatch")
+	}
+	y := NewHamiltonVector(n)
+	for i := 0; i < n; i++ {
+		y[i].Copy(b[perm[i]])
+		for j := 0; j < i; j++ {
+			y[i].Sub(y[i], new(Hamilton).Mul(l[i][j], y[j]))
+		}
+	}
+	x := NewHamiltonVector(n)
+	for i := n - 1; i >= 0; i-- {
+		s := new(Hamilton).Copy(y[i])
+		for j := i + 1; j < n; j++ {
+			s.Sub(s, new(Hamilton).Mul(u[i][j], x[j]))
+		}
+		x[i].Mul(new(Hamilton).Inv(u[i][i]), s)
+	}
+	return x
+}
+
+// QR returns the QR decomposition m = q*r of m, computed with quaternion
+// Householder reflections: q is unitary (i.e. ConjTranspose(q)*q = 1) and r is
+// upper triangular.
+func (m HamiltonMatrix) QR() (q, r HamiltonMatrix) {
+	rows, cols := m.Dims()
+	r = m.Copy()
+	q = IdentityHamiltonMatrix(rows)
+	for k := 0; k < cols && k < rows-1; k++ {
+		x := make(HamiltonVector, rows-k)
+		for i := range x {
+			x[i] = r[k+i][k]
+		}
+		norm := x.Norm()
+		if norm == 0 {
+			continue
+		}
+		// With α = -u|x| for the unit u in the direction of x[0], the
+		// reflection 1 - 2vv*/(v*v) with v = x - αe maps x to αe.
+		u := NewHamilton(1, 0, 0, 0)
+		if !x[0].Equals(zeroH) {
+			u.Normalize(x[0])
+		}
+		v := x.Copy()
+		v[0].Add(v[0], new(Hamilton).Dil(u, norm))
+		vv := v.Dot(v)
+		s := 2 / real(vv[0])
+		for j := k; j < cols; j++ {
+			var w Hamilton
+			for i := range v {
+				w.Add(&w, new(Hamilton).Mul(new(Hamilton).Conj(v[i]), r[k+i][j]))
+			}
+			for i := range v {
+				r[k+i][j].Sub(r[k+i][j], new(Hamilton).Dil(new(Hamilton).Mul(v[i], &w), s))
+			}
+		}
+		for i := 0; i < rows; i++ {
+			var w Hamilton
+			for j := range v {
+				w.Add(&w, new(Hamilton).Mul(q[i][k+j], v[j]))
+			}
+			for j := range v {
+				q[i][k+j].Sub(q[i][k+j], new(Hamilton).Dil(new(Hamilton).Mul(&w, new(Hamilton).Conj(v[j])), s))
+			}
+		}
+		for i := k + 1; i < rows; i++ {
+			r[i][k] = new(Hamilton)
+		}
+	}
+	return q, r
+}
+
+// ComplexAdjoint returns the 2n×2n complex adjoint of the n×n matrix
+// m = a + b*j, with a and b complex matrices made from the two complex128
+// components of each entry:
+//
+//	[ a        b       ]
+//	[ -Conj(b) Conj(a) ]
+//
+// The complex adjoint of a product is the product of the complex adjoints.
+func (m HamiltonMatrix) ComplexAdjoint() [][]complex128 {
+	r, c := m.Dims()
+	x := make([][]complex128, 2*r)
+	for i := range x {
+		x[i] = make([]complex128, 2*c)
+	}
+	for i := range m {
+		for j, z := range m[i] {
+			x[i][j] = z[0]
+			x[i][c+j] = z[1]
+			x[r+i][j] = -cmplx.Conj(z[1])
+			x[r+i][c+j] = cmplx.Conj(z[0])
+		}
+	}
+	return x
+}
+
+// StudyDet returns the Study determinant of the square matrix m, which is the
+// determinant of its complex adjoint. It is real and non-negative, and it
+// vanishes if and only if m is singular. If m is not square, then StudyDet
+// panics.
+func (m HamiltonMatrix) StudyDet() float64 {
+	m.isSquare()
+	return real(complexDet(m.ComplexAdjoint()))
+}
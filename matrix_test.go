@@ -0,0 +1,127 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "testing"
+
+// testHamiltonMatrix returns a fixed non-singular 3×3 HamiltonMatrix.
+func testHamiltonMatrix() HamiltonMatrix {
+	return HamiltonMatrix{
+		{NewHamilton(1, 2, 0, -1), NewHamilton(0, 1, 1, 0), NewHamilton(3, 0, 0, 2)},
+		{NewHamilton(0, 0, 1, 1), NewHamilton(2, -1, 0, 0), NewHamilton(1, 1, 1, 1)},
+		{NewHamilton(-1, 0, 2, 0), NewHamilton(0, 0, 0, 3), NewHamilton(0.5, -2, 1, 0)},
+	}
+}
+
+// closeHamiltonMatrix returns true if the entries of m and n are close.
+func closeHamiltonMatrix(m, n HamiltonMatrix) bool {
+	for i := range m {
+		for j := range m[i] {
+			if !m[i][j].ApproxEquals(n[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestHamiltonMatrixMul(t *testing.T) {
+	m := testHamiltonMatrix()
+	id := IdentityHamiltonMatrix(3)
+	if p := m.Mul(id); !closeHamiltonMatrix(p, m) {
+		t.Errorf("m*1 = %v, want %v", p, m)
+	}
+	// The conjugate transpose reverses products.
+	n := m.ScaleRight(NewHamilton(0, 1, 2, 0))
+	p := m.Mul(n).ConjTranspose()
+	if q := n.ConjTranspose().Mul(m.ConjTranspose()); !closeHamiltonMatrix(p, q) {
+		t.Errorf("(m*n)* = %v, want %v", p, q)
+	}
+	v := HamiltonVector{NewHamilton(1, 0, 0, 0), NewHamilton(0, 1, 0, 0), NewHamilton(0, 0, 1, 0)}
+	a := NewHamilton(0, 0, 0, 1)
+	got := m.MulVec(v.ScaleRight(a))
+	want := m.MulVec(v).ScaleRight(a)
+	for i := range got {
+		if !got[i].ApproxEquals(want[i]) {
+			t.Errorf("m*(v*a)[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHamiltonMatrixLU(t *testing.T) {
+	m := testHamiltonMatrix()
+	l, u, perm := m.LU()
+	p := l.Mul(u)
+	for i := range p {
+		for j := range p[i] {
+			if !p[i][j].ApproxEquals(m[perm[i]][j]) {
+				t.Errorf("(l*u)[%d][%d] = %v, want %v", i, j, p[i][j], m[perm[i]][j])
+			}
+			if i > j && !u[i][j].Equals(zeroH) {
+				t.Errorf("u[%d][%d] = %v, want 0", i, j, u[i][j])
+			}
+		}
+	}
+}
+
+func TestHamiltonMatrixSolve(t *testing.T) {
+	m := testHamiltonMatrix()
+	x := HamiltonVector{NewHamilton(1, -1, 0, 2), NewHamilton(0, 0.5, 3, 0), NewHamilton(-2, 0, 1, 1)}
+	got := m.Solve(m.MulVec(x))
+	for i := range x {
+		if !got[i].ApproxEquals(x[i]) {
+			t.Errorf("x[%d] = %v, want %v", i, got[i], x[i])
+		}
+	}
+}
+
+func TestHamiltonMatrixQR(t *testing.T) {
+	m := append(testHamiltonMatrix(), HamiltonVector{NewHamilton(1, 1, 0, 0), new(Hamilton), NewHamilton(0, 0, 2, 0)})
+	q, r := m.QR()
+	if p := q.Mul(r); !closeHamiltonMatrix(p, m) {
+		t.Errorf("q*r = %v, want %v", p, m)
+	}
+	if p := q.ConjTranspose().Mul(q); !closeHamiltonMatrix(p, IdentityHamiltonMatrix(4)) {
+		t.Errorf("q*q = %v, want 1", p)
+	}
+	for i := range r {
+		for j := 0; j < i && j < len(r[i]); j++ {
+			if !r[i][j].Equals(zeroH) {
+				t.Errorf("r[%d][%d] = %v, want 0", i, j, r[i][j])
+			}
+		}
+	}
+}
+
+func TestHamiltonMatrixStudyDet(t *testing.T) {
+	// The Study determinant of a 1×1 matrix is the quadrance of its entry.
+	z := NewHamilton(1, 2, -1, 0.5)
+	if d := (HamiltonMatrix{{z}}).StudyDet(); notEquals(d, z.Quad()) {
+		t.Errorf("StudyDet(%v) = %v, want %v", z, d, z.Quad())
+	}
+	m := testHamiltonMatrix()
+	n := m.ScaleLeft(NewHamilton(1, 1, 0, 0))
+	if d, e := m.Mul(n).StudyDet(), m.StudyDet()*n.StudyDet(); notEquals(d/e, 1) {
+		t.Errorf("StudyDet(m*n) = %v, want %v", d, e)
+	}
+	// The second row is k times the first row.
+	row := HamiltonVector{NewHamilton(0, 1, 0, 0), NewHamilton(0, 0, 1, 0)}
+	s := HamiltonMatrix{row, row.ScaleLeft(NewHamilton(0, 0, 0, 1))}
+	if d := s.StudyDet(); notEquals(d, 0) {
+		t.Errorf("StudyDet of a singular matrix = %v, want 0", d)
+	}
+}
+
+func TestHamiltonMatrixSolvePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Solve of a singular system did not panic")
+		}
+	}()
+	m := HamiltonMatrix{
+		{NewHamilton(0, 1, 0, 0), NewHamilton(0, 0, 1, 0)},
+		{NewHamilton(0, 0, 1, 0), NewHamilton(0, -1, 0, 0)},
+	}
+	m.Solve(HamiltonVector{NewHamilton(1, 0, 0, 0), NewHamilton(1, 0, 0, 0)})
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// An MEKF is a multiplicative extended Kalman filter for attitude and
+// gyroscope bias, with the conventions of the attitude filters: Q rotates
+// body vectors into the reference frame, and the gyroscope measures the body
+// angular velocity plus Bias.
+//
+// The attitude error is the rotation vector δθ in the body frame, with
+// true attitude Q*Exp(δθ/2), and the bias error is δb. P is the 6×6
+// covariance of the error state (δθ, δb). Each update estimates the error
+// state, applies it to Q and Bias, and resets it to zero, so that Q stays a
+// unit.
+type MEKF struct {
+	Q    *Hamilton
+	Bias Vec3
+	P    [6][6]float64
+
+	// GyroNoise is the angle random walk (in rad/√s) and BiasNoise is the
+	// rate random walk (in rad/s/√s) of the gyroscope.
+	GyroNoise, BiasNoise float64
+}
+
+// NewMEKF returns an MEKF starting from the attitude q and zero bias, with
+// standard deviations σθ for each attitude error component and σb for each
+// bias component, and with the given gyroscope noise densities.
+func NewMEKF(q *Hamilton, σθ, σb, gyroNoise, biasNoise float64) *MEKF {
+	f := &MEKF{Q: new(Hamilton).Copy(q), GyroNoise: gyroNoise, BiasNoise: biasNoise}
+	for i := 0; i < 3; i++ {
+		f.P[i][i] = σθ * σθ
+		f.P[i+3][i+3] = σb * σb
+	}
+	return f
+}
+
+// rows returns P as a slice of rows.
+func (f *MEKF) rows() [][]float64 {
+	p := make([][]float64, 6)
+	for i := range p {
+		p[i] = append([]float64(nil), f.P[i][:]...)
+	}
+	return p
+}
+
+// setRows sets P from a slice of rows, symmetrizing it.
+func (f *MEKF) setRows(p [][]float64) {
+	for i := range f.P {
+		for j := range f.P[i] {
+			f.P[i][j] = (p[i][j] + p[j][i]) / 2
+		}
+	}
+}
+
+// Propagate advances f by dt with the gyroscope sample gyro. The attitude
+// follows the quaternion kinematics with the bias-corrected angular
+// velocity, and the covariance follows the linearized error dynamics
+// dδθ/dt = -ω×δθ - δb.
+func (f *MEKF) Propagate(gyro Vec3, dt float64) {
+	ω := gyro.Sub(f.Bias)
+	f.Q = ExpStep(f.Q, ω, dt)
+	// The transition of δθ is the rotation exp(-[ω×]dt).
+	r := Ad(ExpMap(ω.Scale(-dt)))
+	φ := make([][]float64, 6)
+	for i := range φ {
+		φ[i] = make([]float64, 6)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			φ[i][j] = r[i][j]
+		}
+		φ[i][i+3] = -dt
+		φ[i+3][i+3] = 1
+	}
+	p := matMul(matMul(φ, f.rows()), matTranspose(φ))
+	g2, b2 := f.GyroNoise*f.GyroNoise, f.BiasNoise*f.BiasNoise
+	for i := 0; i < 3; i++ {
+		p[i][i] += g2*dt + b2*dt*dt*dt/3
+		p[i][i+3] -= b2 * dt * dt / 2
+		p[i+3][i] -= b2 * dt * dt / 2
+		p[i+3][i+3] += b2 * dt
+	}
+	f.setRows(p)
+}
+
+// update applies the measurement residual y with the 3×6 measurement matrix
+// h and the measurement noise variance σ² on each component, using the
+// Joseph form of the covariance update.
+func (f *MEKF) update(y Vec3, h [][]float64, σ float64) {
+	p := f.rows()
+	ht := matTranspose(h)
+	s := matMul(matMul(h, p), ht)
+	for i := range s {
+		s[i][i] += σ * σ
+	}
+	k := matMul(matMul(p, ht), matInverse(s))
+	var dx [6]float64
+	for i := range dx {
+		for j := 0; j < 3; j++ {
+			dx[i] += k[i][j] * y[j]
+		}
+	}
+	ikh := matMul(k, h)
+	for i := range ikh {
+		for j := range ikh[i] {
+			ikh[i][j] = -ikh[i][j]
+		}
+		ikh[i][i]++
+	}
+	p = matMul(matMul(ikh, p), matTranspose(ikh))
+	krk := matMul(k, matTranspose(k))
+	for i := range p {
+		for j := range p[i] {
+			p[i][j] += σ * σ * krk[i][j]
+		}
+	}
+	f.setRows(p)
+	f.Q = BoxPlus(f.Q, Vec3{dx[0], dx[1], dx[2]})
+	f.Bias = f.Bias.Add(Vec3{dx[3], dx[4], dx[5]})
+}
+
+// UpdateVector corrects f with the body-frame measurement meas of the
+// reference-frame direction ref, with standard deviation σ (in radians) on
+// each component. Both directions are normalized. The predicted measurement
+// is p = Inv(Q).Rotate(ref), and the measurement matrix is [[p×] 0].
+func (f *MEKF) UpdateVector(ref, meas Vec3, σ float64) {
+	p := new(Hamilton).Conj(f.Q).Rotate(ref.Unit())
+	k := Skew(p)
+	h := make([][]float64, 3)
+	for i := range h {
+		h[i] = make([]float64, 6)
+		copy(h[i], k[i][:])
+	}
+	f.update(meas.Unit().Sub(p), h, σ)
+}
+
+// UpdateQuaternion corrects f with a direct measurement meas of the attitude,
+// such as one from a star tracker, with standard deviation σ (in radians) on
+// each component of its rotation vector error. The residual is the rotation
+// vector of Inv(Q)*meas, and the measurement matrix is [I 0].
+func (f *MEKF) UpdateQuaternion(meas *Hamilton, σ float64) {
+	h := make([][]float64, 3)
+	for i := range h {
+		h[i] = make([]float64, 6)
+		h[i][i] = 1
+	}
+	f.update(BoxMinus(meas, f.Q), h, σ)
+}
+
+// AttitudeSigma returns the standard deviations of the components of the
+// attitude error, from the diagonal of P.
+func (f *MEKF) AttitudeSigma() Vec3 {
+	return Vec3{math.Sqrt(f.P[0][0]), math.Sqrt(f.P[1][1]), math.Sqrt(f.P[2][2])}
+}
+
+// BiasSigma returns the standard deviations of the components of the bias
+// error, from the diagonal of P.
+func (f *MEKF) BiasSigma() Vec3 {
+	return Vec3{math.Sqrt(f.P[3][3]), math.Sqrt(f.P[4][4]), math.Sqrt(f.P[5][5])}
+}
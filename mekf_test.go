@@ -0,0 +1,97 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// spacecraftScenario simulates a slowly tumbling spacecraft with a biased
+// and noisy gyroscope, a sun sensor, a magnetometer, and an occasional star
+// tracker, and runs an MEKF on the measurements. It returns the filter and
+// the true attitude and bias at the end.
+func spacecraftScenario(f *MEKF, rng *rand.Rand, n int, dt float64, bias Vec3, tracker bool) (q *Hamilton, b Vec3) {
+	const (
+		gyroNoise   = 1e-4
+		vectorSigma = 5e-3
+		starSigma   = 1e-4
+	)
+	sun := Vec3{1, 0.5, 0.2}.Unit()
+	field := Vec3{0.3, -0.8, 0.5}.Unit()
+	noise := func(σ float64) Vec3 {
+		return Vec3{σ * rng.NormFloat64(), σ * rng.NormFloat64(), σ * rng.NormFloat64()}
+	}
+	q = RotationHamilton(Vec3{1, 2, 3}.Unit(), 1.2)
+	for k := 0; k < n; k++ {
+		t := float64(k) * dt
+		ω := Vec3{0.02 * math.Sin(0.05*t), 0.01, -0.015 * math.Cos(0.03*t)}
+		q = ExpStep(q, ω, dt)
+		f.Propagate(ω.Add(bias).Add(noise(gyroNoise/math.Sqrt(dt))), dt)
+		inv := new(Hamilton).Conj(q)
+		if k%10 == 0 {
+			f.UpdateVector(sun, inv.Rotate(sun).Add(noise(vectorSigma)), vectorSigma)
+			f.UpdateVector(field, inv.Rotate(field).Add(noise(vectorSigma)), vectorSigma)
+		}
+		if tracker && k%100 == 0 {
+			m := BoxPlus(q, noise(starSigma))
+			f.UpdateQuaternion(m, starSigma)
+		}
+	}
+	return q, bias
+}
+
+func TestMEKFSpacecraft(t *testing.T) {
+	for _, tracker := range []bool{false, true} {
+		rng := rand.New(rand.NewSource(1))
+		bias := Vec3{2e-3, -1e-3, 1.5e-3}
+		// Start 10 degrees off with an unknown bias.
+		q0 := RotationHamilton(Vec3{1, 2, 3}.Unit(), 1.2)
+		q0.Mul(q0, RotationHamilton(Vec3{0, 1, 1}.Unit(), 10*math.Pi/180))
+		f := NewMEKF(q0, 0.2, 5e-3, 1e-4, 1e-6)
+		p0 := f.P
+		q, b := spacecraftScenario(f, rng, 6000, 0.1, bias, tracker)
+		δθ := BoxMinus(q, f.Q)
+		δb := b.Sub(f.Bias)
+		σθ, σb := f.AttitudeSigma(), f.BiasSigma()
+		for i := 0; i < 3; i++ {
+			if math.Abs(δθ[i]) > 3*σθ[i] {
+				t.Errorf("tracker %v: attitude error %v exceeds 3σ %v", tracker, δθ, σθ)
+			}
+			if math.Abs(δb[i]) > 3*σb[i] {
+				t.Errorf("tracker %v: bias error %v exceeds 3σ %v", tracker, δb, σb)
+			}
+			if f.P[i][i] >= p0[i][i] || f.P[i+3][i+3] >= p0[i+3][i+3] {
+				t.Errorf("tracker %v: covariance did not shrink: %v", tracker, f.P)
+			}
+		}
+		if δθ.Norm() > 1e-2 {
+			t.Errorf("tracker %v: attitude error %v is too large", tracker, δθ.Norm())
+		}
+		if δb.Norm() > 2e-4 {
+			t.Errorf("tracker %v: bias error %v is too large", tracker, δb.Norm())
+		}
+		for i := range f.P {
+			for j := range f.P[i] {
+				if f.P[i][j] != f.P[j][i] {
+					t.Fatalf("tracker %v: covariance is not symmetric", tracker)
+				}
+			}
+		}
+	}
+}
+
+func TestMEKFUpdateQuaternion(t *testing.T) {
+	// A precise measurement of the attitude moves the estimate to it.
+	q := RotationHamilton(Vec3{0, 0, 1}, 0.3)
+	f := NewMEKF(NewHamilton(1, 0, 0, 0), 1, 1e-3, 0, 0)
+	f.UpdateQuaternion(new(Hamilton).Neg(q), 1e-6)
+	if e := BoxMinus(q, f.Q).Norm(); e > 1e-5 {
+		t.Errorf("attitude error %v after precise update", e)
+	}
+	if s := f.AttitudeSigma(); s[2] > 2e-6 {
+		t.Errorf("AttitudeSigma = %v after precise update", s)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// The functions in this file view the vector part bi + ct + du of a Cockle
+// value as the vector (b, c, d) of 2+1 Minkowski space, with time b and
+// position (c, d). The quadrance of the vector part is b² - c² - d², with
+// signature (+, -, -). A Cockle value q with non-zero quadrance acts on
+// vectors by v ↦ q v q⁻¹, which is a Lorentz transformation. Values with
+// positive quadrance preserve the direction of time, and values with negative
+// quadrance reverse it.
+
+// RotationCockle returns the unit Cockle value of the rotation by θ in the
+// t-u plane.
+func RotationCockle(θ float64) *Cockle {
+	return RectCockle(1, 0, θ/2, 0, +1)
+}
+
+// BoostCockle returns the unit Cockle value of the boost by the rapidity ξ in
+// the direction cos(φ)t + sin(φ)u. It maps the vector at rest i to
+// cosh(ξ)i + sinh(ξ)(cos(φ)t + sin(φ)u).
+func BoostCockle(ξ, φ float64) *Cockle {
+	return RectCockle(1, ξ/2, 0, φ+math.Pi/2, +1)
+}
+
+// NullRotationCockle returns the unit Cockle value 1 + (s/2)(i - u) of the
+// null rotation with parameter s, which fixes the null vector i - u.
+func NullRotationCockle(s float64) *Cockle {
+	return NewCockle(1, s/2, 0, -s/2)
+}
+
+// Transform returns the image of the vector v = (b, c, d) under the Lorentz
+// transformation v ↦ z v z⁻¹. If z is a zero divisor, then Transform panics.
+func (z *Cockle) Transform(v Vec3) Vec3 {
+	p := NewCockle(0, v[0], v[1], v[2])
+	p.Mul(z, p)
+	p.Mul(p, new(Cockle).Inv(z))
+	return Vec3{imag(p[0]), real(p[1]), imag(p[1])}
+}
+
+// sheet returns the factor s of z = s*w, where w has positive quadrance: s is
+// 1 if the quadrance of z is positive, and the time reversal t, with t² = 1
+// and quadrance -1, if it is negative. If z is a zero divisor, then sheet
+// panics.
+func (z *Cockle) sheet() (s, w *Cockle) {
+	if z.IsZeroDiv() {
+		panic("decomposition of zero divisor")
+	}
+	if z.Quad() > 0 {
+		return NewCockle(1, 0, 0, 0), new(Cockle).Copy(z)
+	}
+	s = NewCockle(0, 0, 1, 0)
+	return s, new(Cockle).Mul(s, z)
+}
+
+// Cartan returns the decomposition z/√|Quad(z)| = s*k*b of z into a sheet
+// factor s, a rotation k and a boost b. The factor s is 1 if the quadrance of
+// z is positive, and the time reversal t if it is negative. With w = s⁻¹z,
+// the rest is read off from the curvilinear coordinates of w: if Curv returns
+// ξ, θ1, and θ2, then k is RotationCockle(2θ1) and b is the boost by the
+// rapidity 2ξ in the direction θ2 - θ1 - π/2. If z is a zero divisor, then
+// Cartan panics.
+func (z *Cockle) Cartan() (s, k, b *Cockle) {
+	s, w := z.sheet()
+	_, ξ, θ1, θ2, _ := w.Curv()
+	return s, RectCockle(1, 0, θ1, 0, +1), RectCockle(1, ξ, 0, θ2-θ1, +1)
+}
+
+// Iwasawa returns the decomposition z/√|Quad(z)| = s*k*a*n of z into a sheet
+// factor s, as in Cartan, a rotation k, a boost a in the direction -u, and a
+// null rotation n. It is computed from the QR decomposition of the matrix of
+// s⁻¹z in SL(2, ℝ). If z is a zero divisor, then Iwasawa panics.
+func (z *Cockle) Iwasawa() (s, k, a, n *Cockle) {
+	s, w := z.sheet()
+	m := w.Mobius()
+	ρ := math.Hypot(m[0][0], m[1][0])
+	c, σ := m[0][0]/ρ, -m[1][0]/ρ
+	x := (c*m[0][1] - σ*m[1][1]) / ρ
+	return s, RotationCockle(2 * math.Atan2(σ, c)),
+		BoostCockle(2*math.Log(ρ), -math.Pi/2),
+		NullRotationCockle(x)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+// minkowskiQuad returns the quadrance b² - c² - d² of the vector (b, c, d).
+func minkowskiQuad(v Vec3) float64 {
+	return v[0]*v[0] - v[1]*v[1] - v[2]*v[2]
+}
+
+func TestCockleTransform(t *testing.T) {
+	var tests = []struct {
+		z    *Cockle
+		v    Vec3
+		want Vec3
+	}{
+		{RotationCockle(math.Pi / 2), Vec3{0, 1, 0}, Vec3{0, 0, 1}},
+		{RotationCockle(0.7), Vec3{1, 0, 0}, Vec3{1, 0, 0}},
+		{BoostCockle(0.5, 0), Vec3{1, 0, 0}, Vec3{math.Cosh(0.5), math.Sinh(0.5), 0}},
+		{BoostCockle(0.5, 1), Vec3{1, 0, 0}, Vec3{math.Cosh(0.5), math.Sinh(0.5) * math.Cos(1), math.Sinh(0.5) * math.Sin(1)}},
+		{NullRotationCockle(1.3), Vec3{1, 0, -1}, Vec3{1, 0, -1}},
+		{NewCockle(0, 0, 1, 0), Vec3{1, 0, 0}, Vec3{-1, 0, 0}},
+	}
+	for _, tt := range tests {
+		if got := tt.z.Transform(tt.v); !closeVec3(got, tt.want, 1e-12) {
+			t.Errorf("Transform(%v, %v) = %v, want %v", tt.z, tt.v, got, tt.want)
+		}
+	}
+	z := NewCockle(1.5, -0.3, 0.8, 0.4)
+	v := Vec3{0.2, -1.1, 0.7}
+	if q, r := minkowskiQuad(v), minkowskiQuad(z.Transform(v)); notEquals(q, r) {
+		t.Errorf("quadrance changed from %v to %v", q, r)
+	}
+}
+
+// cockleDecompositionTests are values on both sheets, with positive and
+// negative quadrance.
+var cockleDecompositionTests = []*Cockle{
+	NewCockle(1.5, -0.3, 0.8, 0.4),
+	NewCockle(0.3, -0.2, 1.4, 0.6),
+	NewCockle(0, 0, 1, 0),
+}
+
+// unitCockle returns z/√|Quad(z)|.
+func unitCockle(z *Cockle) *Cockle {
+	return new(Cockle).Dil(z, 1/math.Sqrt(math.Abs(z.Quad())))
+}
+
+// checkSheet reports an error if s is not the sheet factor of z.
+func checkSheet(t *testing.T, z, s *Cockle) {
+	t.Helper()
+	want := NewCockle(1, 0, 0, 0)
+	if z.Quad() < 0 {
+		want = NewCockle(0, 0, 1, 0)
+	}
+	if !s.Equals(want) {
+		t.Errorf("sheet factor of %v = %v, want %v", z, s, want)
+	}
+}
+
+func TestCockleCartan(t *testing.T) {
+	for _, z := range cockleDecompositionTests {
+		s, k, b := z.Cartan()
+		checkSheet(t, z, s)
+		got := new(Cockle).Mul(s, k)
+		got.Mul(got, b)
+		if want := unitCockle(z); !got.Equals(want) {
+			t.Errorf("s*k*b = %v, want %v", got, want)
+		}
+		if v := k.Transform(Vec3{1, 0, 0}); !closeVec3(v, Vec3{1, 0, 0}, 1e-12) {
+			t.Errorf("rotation %v moves i to %v", k, v)
+		}
+		if notEquals(imag(b[0]), 0) {
+			t.Errorf("boost %v has an i component", b)
+		}
+	}
+}
+
+func TestCockleIwasawa(t *testing.T) {
+	for _, z := range cockleDecompositionTests {
+		s, k, a, n := z.Iwasawa()
+		checkSheet(t, z, s)
+		got := new(Cockle).Mul(s, k)
+		got.Mul(got, a)
+		got.Mul(got, n)
+		if want := unitCockle(z); !got.Equals(want) {
+			t.Errorf("s*k*a*n = %v, want %v", got, want)
+		}
+		if v := n.Transform(Vec3{1, 0, -1}); !closeVec3(v, Vec3{1, 0, -1}, 1e-12) {
+			t.Errorf("null rotation %v moves i - u to %v", n, v)
+		}
+	}
+}
+
+func TestCockleCartanPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Cartan of a zero divisor did not panic")
+		}
+	}()
+	NewCockle(1, 0, 1, 0).Cartan()
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// A Mobius represents the real Möbius transformation
+//
+//	w ↦ (m[0][0]*w + m[0][1]) / (m[1][0]*w + m[1][1])
+//
+// as a 2×2 matrix with unit determinant (i.e. an element of SL(2, ℝ)). It maps
+// the upper half-plane onto itself.
+type Mobius [2][2]float64
+
+// A MobiusClass is the conjugacy type of a Möbius transformation.
+type MobiusClass int
+
+// The conjugacy types of Möbius transformations.
+const (
+	MobiusIdentity MobiusClass = iota
+	MobiusElliptic
+	MobiusParabolic
+	MobiusHyperbolic
+)
+
+// String returns the name of c.
+func (c MobiusClass) String() string {
+	switch c {
+	case MobiusIdentity:
+		return "identity"
+	case MobiusElliptic:
+		return "elliptic"
+	case MobiusParabolic:
+		return "parabolic"
+	case MobiusHyperbolic:
+		return "hyperbolic"
+	}
+	return "unknown"
+}
+
+// cockleMatrix returns the real 2×2 matrix of z under the algebra isomorphism
+// from Cockle quaternions to 2×2 real matrices with
+//
+//	i ↦ [[0, 1], [-1, 0]]
+//	t ↦ [[1, 0], [0, -1]]
+//	u ↦ [[0, -1], [-1, 0]]
+//
+// The determinant of the matrix equals the quadrance of z.
+func cockleMatrix(z *Cockle) [2][2]float64 {
+	a, b := real(z[0]), imag(z[0])
+	c, d := real(z[1]), imag(z[1])
+	return [2][2]float64{
+		{a + c, b - d},
+		{-b - d, a - c},
+	}
+}
+
+// cockleFromMatrix returns the Cockle value of a real 2×2 matrix under the
+// isomorphism of cockleMatrix.
+func cockleFromMatrix(m [2][2]float64) *Cockle {
+	return NewCockle(
+		(m[0][0]+m[1][1])/2,
+		(m[0][1]-m[1][0])/2,
+		(m[0][0]-m[1][1])/2,
+		-(m[0][1]+m[1][0])/2,
+	)
+}
+
+// Mobius returns the Möbius transformation of z, whose matrix is that of z
+// divided by the square root of its quadrance. If the quadrance of z is not
+// positive, then Mobius panics.
+func (z *Cockle) Mobius() Mobius {
+	q := z.Quad()
+	if q <= 0 {
+		panic("quadrance is not positive")
+	}
+	m := cockleMatrix(z)
+	s := 1 / math.Sqrt(q)
+	return Mobius{
+		{s * m[0][0], s * m[0][1]},
+		{s * m[1][0], s * m[1][1]},
+	}
+}
+
+// Cockle returns the unit Cockle value of m.
+func (m Mobius) Cockle() *Cockle {
+	return cockleFromMatrix(m)
+}
+
+// Apply returns the image of a point w of the upper half-plane under m. The
+// point at infinity is represented by cmplx.Inf().
+func (m Mobius) Apply(w complex128) complex128 {
+	if cmplx.IsInf(w) {
+		if m[1][0] == 0 {
+			return cmplx.Inf()
+		}
+		return complex(m[0][0]/m[1][0], 0)
+	}
+	d := complex(m[1][0], 0)*w + complex(m[1][1], 0)
+	if d == 0 {
+		return cmplx.Inf()
+	}
+	return (complex(m[0][0], 0)*w + complex(m[0][1], 0)) / d
+}
+
+// ApplyDisk returns the image of a point ζ of the Poincaré disk under m,
+// using the Cayley transform w ↦ (w - i)/(w + i) from the upper half-plane to
+// the disk.
+func (m Mobius) ApplyDisk(ζ complex128) complex128 {
+	if ζ == 1 {
+		return halfPlaneToDisk(m.Apply(cmplx.Inf()))
+	}
+	return halfPlaneToDisk(m.Apply(1i * (1 + ζ) / (1 - ζ)))
+}
+
+// halfPlaneToDisk returns the image of w under the Cayley transform.
+func halfPlaneToDisk(w complex128) complex128 {
+	if cmplx.IsInf(w) {
+		return 1
+	}
+	return (w - 1i) / (w + 1i)
+}
+
+// Trace returns the trace of the matrix of m.
+func (m Mobius) Trace() float64 {
+	return m[0][0] + m[1][1]
+}
+
+// Class returns the conjugacy type of m: elliptic if |Trace| < 2, parabolic if
+// |Trace| = 2 (and m is not ±1), and hyperbolic if |Trace| > 2.
+func (m Mobius) Class() MobiusClass {
+	t := math.Abs(m.Trace())
+	switch {
+	case notEquals(t, 2) && t < 2:
+		return MobiusElliptic
+	case notEquals(t, 2):
+		return MobiusHyperbolic
+	case !notEquals(m[0][1], 0) && !notEquals(m[1][0], 0):
+		return MobiusIdentity
+	}
+	return MobiusParabolic
+}
+
+// FixedPoints returns the fixed points of m. An elliptic m has one fixed
+// point in the upper half-plane, returned first, and its complex conjugate. A
+// hyperbolic m has two fixed points on the extended real line: the repelling
+// one first, and the attracting one second. A parabolic m has a single fixed
+// point, returned twice. The point at infinity is represented by cmplx.Inf().
+// For the identity, both fixed points are NaN.
+func (m Mobius) FixedPoints() (p, q complex128) {
+	if m.Class() == MobiusIdentity {
+		return cmplx.NaN(), cmplx.NaN()
+	}
+	a, b, c, d := m[0][0], m[0][1], m[1][0], m[1][1]
+	t := m.Trace()
+	if !notEquals(c, 0) {
+		// Infinity is fixed, and w ↦ (a*w + b)/d fixes b/(d - a).
+		if !notEquals(a, d) {
+			return cmplx.Inf(), cmplx.Inf()
+		}
+		f := complex(b/(d-a), 0)
+		if math.Abs(a) > math.Abs(d) {
+			return f, cmplx.Inf()
+		}
+		return cmplx.Inf(), f
+	}
+	disc := cmplx.Sqrt(complex(t*t-4, 0))
+	if m.Class() == MobiusParabolic {
+		disc = 0
+	}
+	p = (complex(a-d, 0) + disc) / complex(2*c, 0)
+	q = (complex(a-d, 0) - disc) / complex(2*c, 0)
+	switch m.Class() {
+	case MobiusElliptic:
+		if imag(p) < 0 {
+			p, q = q, p
+		}
+	case MobiusHyperbolic:
+		// The derivative at a fixed point f is 1/(c*f + d)², so that p is
+		// attracting when |c*p + d| > 1.
+		if cd := real(p)*c + d; math.Abs(cd) > 1 {
+			p, q = q, p
+		}
+	}
+	return
+}
+
+// TranslationLength returns the hyperbolic distance 2 acosh(|Trace|/2) that a
+// hyperbolic m moves points along its axis. For other classes, it returns 0.
+func (m Mobius) TranslationLength() float64 {
+	if m.Class() != MobiusHyperbolic {
+		return 0
+	}
+	return 2 * math.Acosh(math.Abs(m.Trace())/2)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// closeComplex reports whether w and z differ by at most a small tolerance.
+func closeComplex(w, z complex128) bool {
+	if cmplx.IsInf(w) || cmplx.IsInf(z) {
+		return cmplx.IsInf(w) && cmplx.IsInf(z)
+	}
+	return !notEquals(real(w), real(z)) && !notEquals(imag(w), imag(z))
+}
+
+// halfPlaneDistance returns the hyperbolic distance between two points of the
+// upper half-plane.
+func halfPlaneDistance(w, z complex128) float64 {
+	return math.Acosh(1 + cmplx.Abs(w-z)*cmplx.Abs(w-z)/(2*imag(w)*imag(z)))
+}
+
+func TestCockleMobius(t *testing.T) {
+	x, y := NewCockle(2, 1, 0.5, -1), NewCockle(1, -0.5, 0.25, 0.5)
+	xy := new(Cockle).Mul(x, y)
+	w := complex(0.3, 1.7)
+	got := xy.Mobius().Apply(w)
+	want := x.Mobius().Apply(y.Mobius().Apply(w))
+	if !closeComplex(got, want) {
+		t.Errorf("Mobius(x*y)(%v) = %v, want %v", w, got, want)
+	}
+	if d, e := halfPlaneDistance(w, 2i), halfPlaneDistance(x.Mobius().Apply(w), x.Mobius().Apply(2i)); notEquals(d, e) {
+		t.Errorf("hyperbolic distance changed from %v to %v", d, e)
+	}
+	u := new(Cockle).Dil(x, 1/math.Sqrt(x.Quad()))
+	if c := x.Mobius().Cockle(); !c.Equals(u) {
+		t.Errorf("Cockle(Mobius(%v)) = %v, want %v", x, c, u)
+	}
+}
+
+func TestMobiusClass(t *testing.T) {
+	var tests = []struct {
+		z      *Cockle
+		class  MobiusClass
+		p, q   complex128
+		length float64
+	}{
+		{NewCockle(1, 0, 0, 0), MobiusIdentity, cmplx.NaN(), cmplx.NaN(), 0},
+		{NewCockle(math.Cos(0.4), math.Sin(0.4), 0, 0), MobiusElliptic, 1i, -1i, 0},
+		{NewCockle(math.Cosh(0.5), 0, math.Sinh(0.5), 0), MobiusHyperbolic, 0, cmplx.Inf(), 1},
+		{NewCockle(1, 0.5, 0, -0.5), MobiusParabolic, cmplx.Inf(), cmplx.Inf(), 0},
+		{NewCockle(1, 0.5, 0, 0.5), MobiusParabolic, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		m := tt.z.Mobius()
+		if c := m.Class(); c != tt.class {
+			t.Errorf("Class(%v) = %v, want %v", tt.z, c, tt.class)
+		}
+		if l := m.TranslationLength(); notEquals(l, tt.length) {
+			t.Errorf("TranslationLength(%v) = %v, want %v", tt.z, l, tt.length)
+		}
+		if tt.class == MobiusIdentity {
+			continue
+		}
+		p, q := m.FixedPoints()
+		if !closeComplex(p, tt.p) || !closeComplex(q, tt.q) {
+			t.Errorf("FixedPoints(%v) = %v, %v, want %v, %v", tt.z, p, q, tt.p, tt.q)
+		}
+		if !closeComplex(m.Apply(p), p) {
+			t.Errorf("%v does not fix %v", tt.z, p)
+		}
+	}
+}
+
+func TestMobiusFixedPointsHyperbolic(t *testing.T) {
+	// With c ≠ 0, the fixed points of w ↦ (2w + 1)/(w + 1) are (1 ± √5)/2.
+	// Iterating m converges to the attracting one, and iterating its inverse
+	// converges to the repelling one.
+	m := Mobius{{2, 1}, {1, 1}}
+	inv := Mobius{{1, -1}, {-1, 2}}
+	p, q := m.FixedPoints()
+	if !closeComplex(p, complex((1-math.Sqrt(5))/2, 0)) || !closeComplex(q, complex((1+math.Sqrt(5))/2, 0)) {
+		t.Errorf("FixedPoints(%v) = %v, %v", m, p, q)
+	}
+	w, v := complex(0.3, 0), complex(0.3, 0)
+	for n := 0; n < 100; n++ {
+		w, v = m.Apply(w), inv.Apply(v)
+	}
+	if !closeComplex(w, q) {
+		t.Errorf("iterates of %v converge to %v, want attracting point %v", m, w, q)
+	}
+	if !closeComplex(v, p) {
+		t.Errorf("iterates of the inverse converge to %v, want repelling point %v", v, p)
+	}
+}
+
+func TestMobiusApplyDisk(t *testing.T) {
+	// A rotation about i in the half-plane is a rotation about 0 in the disk.
+	m := NewCockle(math.Cos(0.4), math.Sin(0.4), 0, 0).Mobius()
+	ζ := complex(0.3, -0.2)
+	got := m.ApplyDisk(ζ)
+	if notEquals(cmplx.Abs(got), cmplx.Abs(ζ)) || !closeComplex(m.ApplyDisk(0), 0) {
+		t.Errorf("ApplyDisk(%v) = %v is not a rotation about 0", ζ, got)
+	}
+	if !closeComplex(m.ApplyDisk(1), halfPlaneToDisk(m.Apply(cmplx.Inf()))) {
+		t.Errorf("ApplyDisk(1) = %v", m.ApplyDisk(1))
+	}
+}
+
+func TestMobiusClassString(t *testing.T) {
+	if s := MobiusHyperbolic.String(); s != "hyperbolic" {
+		t.Errorf("String = %q, want %q", s, "hyperbolic")
+	}
+}
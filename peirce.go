@@ -0,0 +1,111 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// IdempotentCockle returns the idempotent ½(1 + v), with v the vector
+// sinh(α)i + cosh(α)(cos(φ)t + sin(φ)u) that squares to 1. Every idempotent
+// other than 0 and 1 is of this form.
+func IdempotentCockle(α, φ float64) *Cockle {
+	s, c := math.Sinh(α), math.Cosh(α)
+	return NewCockle(0.5, s/2, c*math.Cos(φ)/2, c*math.Sin(φ)/2)
+}
+
+// NilpotentCockle returns the nilpotent r(i + cos(φ)t + sin(φ)u), whose
+// square vanishes. Every non-zero nilpotent is of this form.
+func NilpotentCockle(r, φ float64) *Cockle {
+	return NewCockle(0, r, r*math.Cos(φ), r*math.Sin(φ))
+}
+
+// Peirce returns the Peirce decomposition of z with respect to the
+// idempotent e: with f = 1 - e, the four parts are e*z*e, e*z*f, f*z*e, and
+// f*z*f, and they add up to z. If e is not an idempotent, then Peirce panics.
+func (z *Cockle) Peirce(e *Cockle) (ee, ef, fe, ff *Cockle) {
+	if !e.IsIndempotent() {
+		panic("not an idempotent")
+	}
+	f := new(Cockle).Sub(oneK, e)
+	part := func(x, y *Cockle) *Cockle {
+		p := new(Cockle).Mul(x, z)
+		return p.Mul(p, y)
+	}
+	return part(e, e), part(e, f), part(f, e), part(f, f)
+}
+
+// CharacteristicPolynomial returns the coefficients, in ascending order, of
+// the characteristic polynomial x² - 2ax + Quad(z) of z = a + bi + ct + du,
+// which is that of its 2×2 real matrix. Every Cockle value is a root of its
+// characteristic polynomial.
+func (z *Cockle) CharacteristicPolynomial() []float64 {
+	return []float64{z.Quad(), -2 * real(z[0]), 1}
+}
+
+// MinimalPolynomial returns the coefficients, in ascending order, of the
+// monic polynomial of least degree that has z as a root. It is x - a for a
+// real z = a, and the characteristic polynomial otherwise.
+func (z *Cockle) MinimalPolynomial() []float64 {
+	if z.Equals(&Cockle{complex(real(z[0]), 0), 0}) {
+		return []float64{-real(z[0]), 1}
+	}
+	return z.CharacteristicPolynomial()
+}
+
+// Rank returns the rank of the 2×2 real matrix of z: 0 if z is zero, 1 if z
+// is a non-zero zero divisor, and 2 otherwise.
+func (z *Cockle) Rank() int {
+	if z.Equals(zeroK) {
+		return 0
+	}
+	if z.IsZeroDiv() {
+		return 1
+	}
+	return 2
+}
+
+// nullVector returns a unit vector orthogonal to the longer of the vectors
+// (p, q) and (r, s).
+func nullVector(p, q, r, s float64) [2]float64 {
+	if math.Hypot(r, s) > math.Hypot(p, q) {
+		p, q = r, s
+	}
+	h := math.Hypot(p, q)
+	return [2]float64{-q / h, p / h}
+}
+
+// LeftAnnihilator returns a basis of the left annihilator of z, the Cockle
+// values y with y*z = 0. It has 4 elements if z is zero, 2 if z is a non-zero
+// zero divisor, and none otherwise.
+func (z *Cockle) LeftAnnihilator() []*Cockle {
+	switch z.Rank() {
+	case 0:
+		return []*Cockle{NewCockle(1, 0, 0, 0), NewCockle(0, 1, 0, 0), NewCockle(0, 0, 1, 0), NewCockle(0, 0, 0, 1)}
+	case 1:
+		m := cockleMatrix(z)
+		n := nullVector(m[0][0], m[1][0], m[0][1], m[1][1])
+		return []*Cockle{
+			cockleFromMatrix([2][2]float64{{n[0], n[1]}, {0, 0}}),
+			cockleFromMatrix([2][2]float64{{0, 0}, {n[0], n[1]}}),
+		}
+	}
+	return nil
+}
+
+// RightAnnihilator returns a basis of the right annihilator of z, the Cockle
+// values y with z*y = 0. It has 4 elements if z is zero, 2 if z is a non-zero
+// zero divisor, and none otherwise.
+func (z *Cockle) RightAnnihilator() []*Cockle {
+	switch z.Rank() {
+	case 0:
+		return []*Cockle{NewCockle(1, 0, 0, 0), NewCockle(0, 1, 0, 0), NewCockle(0, 0, 1, 0), NewCockle(0, 0, 0, 1)}
+	case 1:
+		m := cockleMatrix(z)
+		k := nullVector(m[0][0], m[0][1], m[1][0], m[1][1])
+		return []*Cockle{
+			cockleFromMatrix([2][2]float64{{k[0], 0}, {k[1], 0}}),
+			cockleFromMatrix([2][2]float64{{0, k[0]}, {0, k[1]}}),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "testing"
+
+// evalCockle returns the value at z of the polynomial with real coefficients
+// p in ascending order.
+func evalCockle(p []float64, z *Cockle) *Cockle {
+	v := new(Cockle)
+	for n := len(p) - 1; n >= 0; n-- {
+		v.Mul(v, z)
+		v.Add(v, NewCockle(p[n], 0, 0, 0))
+	}
+	return v
+}
+
+func TestCocklePeirce(t *testing.T) {
+	e := IdempotentCockle(0.4, 1.2)
+	f := new(Cockle).Sub(oneK, e)
+	z := NewCockle(1, -2, 0.5, 3)
+	ee, ef, fe, ff := z.Peirce(e)
+	sum := new(Cockle).Add(ee, ef)
+	sum.Add(sum, fe)
+	sum.Add(sum, ff)
+	if !sum.Equals(z) {
+		t.Errorf("sum of Peirce parts = %v, want %v", sum, z)
+	}
+	if p := new(Cockle).Mul(f, ef); !p.Equals(zeroK) {
+		t.Errorf("f*ef = %v, want 0", p)
+	}
+	if p := new(Cockle).Mul(e, fe); !p.Equals(zeroK) {
+		t.Errorf("e*fe = %v, want 0", p)
+	}
+	if p := new(Cockle).Mul(ee, e); !p.Equals(ee) {
+		t.Errorf("ee*e = %v, want %v", p, ee)
+	}
+}
+
+func TestCockleMinimalPolynomial(t *testing.T) {
+	var tests = []struct {
+		z      *Cockle
+		degree int
+	}{
+		{NewCockle(3, 0, 0, 0), 1},
+		{NewCockle(1, 2, -1, 0.5), 2},
+		{IdempotentCockle(1, 2), 2},
+		{NilpotentCockle(1, 2), 2},
+	}
+	for _, tt := range tests {
+		p := tt.z.MinimalPolynomial()
+		if len(p)-1 != tt.degree {
+			t.Errorf("MinimalPolynomial(%v) = %v, want degree %v", tt.z, p, tt.degree)
+		}
+		if v := evalCockle(p, tt.z); !v.Equals(zeroK) {
+			t.Errorf("MinimalPolynomial(%v)(%v) = %v, want 0", tt.z, tt.z, v)
+		}
+		if v := evalCockle(tt.z.CharacteristicPolynomial(), tt.z); !v.Equals(zeroK) {
+			t.Errorf("CharacteristicPolynomial(%v)(%v) = %v, want 0", tt.z, tt.z, v)
+		}
+	}
+}
+
+func TestCockleAnnihilator(t *testing.T) {
+	var tests = []struct {
+		z    *Cockle
+		rank int
+	}{
+		{NewCockle(0, 0, 0, 0), 0},
+		{IdempotentCockle(0.3, -1), 1},
+		{NilpotentCockle(2, 0.7), 1},
+		{NewCockle(1, 1, 1, 1), 1},
+		{NewCockle(2, 1, 1, 1), 2},
+	}
+	for _, tt := range tests {
+		if r := tt.z.Rank(); r != tt.rank {
+			t.Errorf("Rank(%v) = %v, want %v", tt.z, r, tt.rank)
+		}
+		left, right := tt.z.LeftAnnihilator(), tt.z.RightAnnihilator()
+		if len(left) != 2*(2-tt.rank) || len(right) != 2*(2-tt.rank) {
+			t.Errorf("annihilators of %v have %v and %v elements", tt.z, len(left), len(right))
+		}
+		for _, y := range left {
+			if p := new(Cockle).Mul(y, tt.z); y.Equals(zeroK) || !p.Equals(zeroK) {
+				t.Errorf("%v is not in the left annihilator of %v", y, tt.z)
+			}
+		}
+		for _, y := range right {
+			if p := new(Cockle).Mul(tt.z, y); y.Equals(zeroK) || !p.Equals(zeroK) {
+				t.Errorf("%v is not in the right annihilator of %v", y, tt.z)
+			}
+		}
+	}
+}
+
+func TestCocklePeircePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Peirce with respect to a non-idempotent did not panic")
+		}
+	}()
+	NewCockle(1, 2, 3, 4).Peirce(NewCockle(0.5, 0.5, 0, 0))
+}
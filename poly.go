@@ -0,0 +1,330 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// A HamiltonPoly represents the unilateral polynomial
+//
+//	p[0] + p[1]*x + p[2]*x² + ... + p[n]*xⁿ
+//
+// with Hamilton coefficients on the left of the powers of x. The variable x
+// commutes with the coefficients when polynomials are multiplied, but not
+// when they are evaluated, so that x - α divides p on the right if and only if
+// α is a root of p.
+type HamiltonPoly []*Hamilton
+
+// Degree returns the degree of p. The degree of the zero polynomial is -1.
+func (p HamiltonPoly) Degree() int {
+	n := len(p) - 1
+	for n >= 0 && p[n].Equals(zeroH) {
+		n--
+	}
+	return n
+}
+
+// Eval returns the value of p at x, computed with Horner's method.
+func (p HamiltonPoly) Eval(x *Hamilton) *Hamilton {
+	v := new(Hamilton)
+	for n := len(p) - 1; n >= 0; n-- {
+		v.Mul(v, x)
+		v.Add(v, p[n])
+	}
+	return v
+}
+
+// Conj returns the polynomial with the conjugates of the coefficients of p.
+func (p HamiltonPoly) Conj() HamiltonPoly {
+	q := make(HamiltonPoly, len(p))
+	for n, a := range p {
+		q[n] = new(Hamilton).Conj(a)
+	}
+	return q
+}
+
+// Mul returns the product of p and q.
+func (p HamiltonPoly) Mul(q HamiltonPoly) HamiltonPoly {
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	r := make(HamiltonPoly, len(p)+len(q)-1)
+	for n := range r {
+		r[n] = new(Hamilton)
+	}
+	for i, a := range p {
+		for j, b := range q {
+			r[i+j].Add(r[i+j], new(Hamilton).Mul(a, b))
+		}
+	}
+	return r
+}
+
+// QuoRem returns the quotient q and the remainder r of the right division of
+// p by d, so that p = q*d + r with the degree of r less than that of d. If d is
+// the zero polynomial, then QuoRem panics.
+func (p HamiltonPoly) QuoRem(d HamiltonPoly) (q, r HamiltonPoly) {
+	m := d.Degree()
+	if m < 0 {
+		panic("division by zero polynomial")
+	}
+	inv := new(Hamilton).Inv(d[m])
+	r = make(HamiltonPoly, len(p))
+	for n, a := range p {
+		r[n] = new(Hamilton).Copy(a)
+	}
+	n := p.Degree()
+	if n < m {
+		return HamiltonPoly{new(Hamilton)}, r
+	}
+	q = make(HamiltonPoly, n-m+1)
+	for k := n; k >= m; k-- {
+		t := new(Hamilton).Mul(r[k], inv)
+		q[k-m] = t
+		for j := 0; j < m; j++ {
+			r[k-m+j].Sub(r[k-m+j], new(Hamilton).Mul(t, d[j]))
+		}
+		r[k] = new(Hamilton)
+	}
+	return q, r[:m]
+}
+
+// A HamiltonRoot is a root of a HamiltonPoly. If Spherical is false, then Root
+// is an isolated root. If Spherical is true, then Root is the representative
+// a + bi of a spherical family of roots, which are all the values similar to
+// it (i.e. a + bu, with u any unit vector).
+type HamiltonRoot struct {
+	Root      *Hamilton
+	Spherical bool
+}
+
+// Roots returns the distinct roots of p, found with the companion polynomial
+// method of Serôdio and Pereira. The companion polynomial p*Conj(p) has real
+// coefficients, and each of its pairs of complex roots a ± bi determines a
+// sphere a + bu of candidate roots. On that sphere p reduces to A*x + B, which
+// vanishes everywhere if A = B = 0, and only at -Inv(A)*B otherwise. Since
+// repeated factors of p, and spheres that hold several roots, give multiple
+// roots of the companion polynomial, which the root finder only finds
+// approximately, the companion polynomial is first divided by its greatest
+// common divisor with its derivative, so that each of its roots is simple.
+func (p HamiltonPoly) Roots() []HamiltonRoot {
+	n := p.Degree()
+	if n < 1 {
+		return nil
+	}
+	p = p[:n+1]
+	var scale float64
+	for _, a := range p {
+		scale = math.Max(scale, math.Sqrt(a.Quad()))
+	}
+	tol := 1e-6 * scale
+	c := p.Mul(p.Conj())
+	coef := make([]float64, len(c))
+	for k, a := range c {
+		coef[k] = real(a[0])
+	}
+	var roots []HamiltonRoot
+	for _, z := range clusterRoots(polyRoots(squareFree(coef))) {
+		a, b := real(z), imag(z)
+		if b < 1e-6*(1+math.Abs(a)) {
+			x := NewHamilton(a, 0, 0, 0)
+			if math.Sqrt(p.Eval(x).Quad()) <= tol {
+				roots = appendRoot(roots, HamiltonRoot{Root: x})
+			}
+			continue
+		}
+		q := HamiltonPoly{NewHamilton(a*a+b*b, 0, 0, 0), NewHamilton(-2*a, 0, 0, 0), NewHamilton(1, 0, 0, 0)}
+		_, r := p.QuoRem(q)
+		A, B := r[1], r[0]
+		if math.Sqrt(A.Quad()) <= tol {
+			if math.Sqrt(B.Quad()) <= tol {
+				roots = appendRoot(roots, HamiltonRoot{Root: NewHamilton(a, b, 0, 0), Spherical: true})
+			}
+			continue
+		}
+		x := new(Hamilton).Mul(new(Hamilton).Inv(A), B)
+		roots = appendRoot(roots, HamiltonRoot{Root: x.Neg(x)})
+	}
+	return roots
+}
+
+// appendRoot appends r to roots, unless roots already holds a root of the
+// same kind that is approximately equal to it.
+func appendRoot(roots []HamiltonRoot, r HamiltonRoot) []HamiltonRoot {
+	for _, s := range roots {
+		if s.Spherical == r.Spherical && math.Sqrt(new(Hamilton).Sub(s.Root, r.Root).Quad()) <= 1e-6*(1+math.Sqrt(r.Root.Quad())) {
+			return roots
+		}
+	}
+	return append(roots, r)
+}
+
+// polyRoots returns the complex roots of the real polynomial with
+// coefficients c in ascending order, using the Durand-Kerner method.
+func polyRoots(c []float64) []complex128 {
+	n := len(c) - 1
+	a := make([]complex128, n+1)
+	bound := 1.0
+	for k := range c {
+		a[k] = complex(c[k]/c[n], 0)
+		if k < n {
+			bound = math.Max(bound, 1+math.Abs(c[k]/c[n]))
+		}
+	}
+	z := make([]complex128, n)
+	for k := range z {
+		z[k] = complex(bound, 0) * cmplx.Pow(0.4+0.9i, complex(float64(k), 0))
+	}
+	for iter := 0; iter < 2000; iter++ {
+		var change float64
+		for k := range z {
+			f := complex(0, 0)
+			for j := n; j >= 0; j-- {
+				f = f*z[k] + a[j]
+			}
+			d := complex(1, 0)
+			for j := range z {
+				if j != k {
+					d *= z[k] - z[j]
+				}
+			}
+			if d == 0 {
+				d = complex(1e-12, 0)
+			}
+			δ := f / d
+			z[k] -= δ
+			change = math.Max(change, cmplx.Abs(δ))
+		}
+		if change < 1e-15*bound {
+			break
+		}
+	}
+	return z
+}
+
+// clusterRoots folds the roots z into the closed upper half-plane, and
+// returns the mean of each group of them that approximate the same root. A
+// group off the real line holds a root and its conjugate.
+func clusterRoots(z []complex128) []complex128 {
+	var sums []complex128
+	var counts []int
+	for _, w := range z {
+		w = complex(real(w), math.Abs(imag(w)))
+		found := false
+		for k, s := range sums {
+			c := s / complex(float64(counts[k]), 0)
+			if cmplx.Abs(w-c) <= 1e-5*(1+cmplx.Abs(c)) {
+				sums[k] += w
+				counts[k]++
+				found = true
+				break
+			}
+		}
+		if !found {
+			sums = append(sums, w)
+			counts = append(counts, 1)
+		}
+	}
+	for k := range sums {
+		sums[k] /= complex(float64(counts[k]), 0)
+	}
+	return sums
+}
+
+// squareFree returns the real polynomial with the distinct roots of the one
+// with coefficients c in ascending order, each as a simple root. It is the
+// quotient of c by the greatest common divisor of c and its derivative.
+func squareFree(c []float64) []float64 {
+	d := make([]float64, len(c)-1)
+	for j := 1; j < len(c); j++ {
+		d[j-1] = float64(j) * c[j]
+	}
+	q, _ := realQuoRem(c, realGCD(c, d))
+	return q
+}
+
+// realGCD returns the monic greatest common divisor of the real polynomials
+// with coefficients a and b in ascending order, computed with the Euclidean
+// algorithm. Remainders that are small relative to the divisor are taken to
+// be zero.
+func realGCD(a, b []float64) []float64 {
+	a, b = trimPoly(a, 0), trimPoly(b, 0)
+	for len(b) > 0 {
+		_, r := realQuoRem(a, b)
+		a, b = b, trimPoly(r, 1e-9*polyNorm(b))
+	}
+	return trimPoly(a, 0)
+}
+
+// realQuoRem returns the quotient and the remainder of the division of the
+// real polynomial a by the real polynomial b, with coefficients in ascending
+// order.
+func realQuoRem(a, b []float64) (q, r []float64) {
+	r = append([]float64(nil), a...)
+	m := len(b) - 1
+	if len(a) <= m {
+		return []float64{0}, r
+	}
+	q = make([]float64, len(a)-m)
+	for k := len(a) - 1; k >= m; k-- {
+		t := r[k] / b[m]
+		q[k-m] = t
+		for j := 0; j <= m; j++ {
+			r[k-m+j] -= t * b[j]
+		}
+	}
+	return q, r[:m]
+}
+
+// trimPoly drops the leading coefficients of c whose absolute values are at
+// most tol, and divides the rest by the leading one, so that the result is
+// monic. The zero polynomial is returned as an empty slice.
+func trimPoly(c []float64, tol float64) []float64 {
+	n := len(c)
+	for n > 0 && math.Abs(c[n-1]) <= tol {
+		n--
+	}
+	p := make([]float64, n)
+	for k := range p {
+		p[k] = c[k] / c[n-1]
+	}
+	return p
+}
+
+// polyNorm returns the largest absolute value of the coefficients c.
+func polyNorm(c []float64) float64 {
+	var m float64
+	for _, x := range c {
+		m = math.Max(m, math.Abs(x))
+	}
+	return m
+}
+
+// QuadraticRoots returns the roots of x² + b*x + c.
+func QuadraticRoots(b, c *Hamilton) []HamiltonRoot {
+	return HamiltonPoly{c, b, NewHamilton(1, 0, 0, 0)}.Roots()
+}
+
+// Sylvester sets z equal to the solution x of the Sylvester equation
+// a*x + x*b = c, and returns z. The equation is solved as a 4×4 real linear
+// system. If the solution is not unique (i.e. if a and -b are similar), then
+// Sylvester panics.
+func (z *Hamilton) Sylvester(a, b, c *Hamilton) *Hamilton {
+	l, r := a.LeftMatrix(), b.RightMatrix()
+	m := make([][]float64, 4)
+	for i := range m {
+		m[i] = make([]float64, 4)
+		for j := range m[i] {
+			m[i][j] = l[i][j] + r[i][j]
+		}
+	}
+	e, f, g, h := c.Cartesian()
+	x, ok := solveLinear(m, []float64{e, f, g, h})
+	if !ok {
+		panic("equation has no unique solution")
+	}
+	return z.Copy(NewHamilton(x[0], x[1], x[2], x[3]))
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "testing"
+
+// linearFactor returns the polynomial x - α.
+func linearFactor(α *Hamilton) HamiltonPoly {
+	return HamiltonPoly{new(Hamilton).Neg(α), NewHamilton(1, 0, 0, 0)}
+}
+
+// isRoot returns true if x is an isolated root in roots.
+func isRoot(roots []HamiltonRoot, x *Hamilton) bool {
+	for _, r := range roots {
+		if !r.Spherical && new(Hamilton).Sub(r.Root, x).Quad() < 1e-10 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHamiltonPolyQuoRem(t *testing.T) {
+	p := HamiltonPoly{NewHamilton(1, 2, 0, -1), NewHamilton(0, 1, 1, 0), NewHamilton(3, 0, 0, 2), NewHamilton(-1, 1, 0.5, 0)}
+	d := HamiltonPoly{NewHamilton(0, 0, 1, 1), NewHamilton(2, -1, 0, 0)}
+	q, r := p.QuoRem(d)
+	if r.Degree() >= d.Degree() {
+		t.Errorf("remainder %v has degree %d", r, r.Degree())
+	}
+	s := q.Mul(d)
+	for n := range r {
+		s[n].Add(s[n], r[n])
+	}
+	for n := range p {
+		if !s[n].ApproxEquals(p[n]) {
+			t.Errorf("coefficient %d of q*d + r = %v, want %v", n, s[n], p[n])
+		}
+	}
+}
+
+func TestHamiltonPolyFactor(t *testing.T) {
+	α := NewHamilton(1, 0, 2, -1)
+	p := HamiltonPoly{NewHamilton(2, 1, 0, 0), NewHamilton(0, 0, 3, 1)}.Mul(linearFactor(α))
+	if v := p.Eval(α); !v.ApproxEquals(zeroH) {
+		t.Errorf("p(α) = %v, want 0", v)
+	}
+	if _, r := p.QuoRem(linearFactor(α)); !r[0].ApproxEquals(zeroH) {
+		t.Errorf("remainder of division by x - α = %v, want 0", r[0])
+	}
+}
+
+func TestHamiltonPolyRoots(t *testing.T) {
+	α, β := NewHamilton(1, 0, 2, 0), NewHamilton(3, 0, 0, -1)
+	sphere := HamiltonPoly{NewHamilton(1, 0, 0, 0), new(Hamilton), NewHamilton(1, 0, 0, 0)}
+
+	// Two isolated roots in different similarity classes.
+	p := linearFactor(β).Mul(linearFactor(α))
+	roots := p.Roots()
+	if len(roots) != 2 || !isRoot(roots, α) {
+		t.Errorf("Roots(%v) = %v, want 2 isolated roots including %v", p, roots, α)
+	}
+	for _, r := range roots {
+		if v := p.Eval(r.Root); !v.ApproxEquals(zeroH) {
+			t.Errorf("p(%v) = %v, want 0", r.Root, v)
+		}
+	}
+
+	// A spherical family and an isolated root.
+	p = sphere.Mul(linearFactor(α))
+	roots = p.Roots()
+	var spherical int
+	for _, r := range roots {
+		if r.Spherical {
+			spherical++
+			if !r.Root.ApproxEquals(NewHamilton(0, 1, 0, 0)) {
+				t.Errorf("spherical root %v, want i", r.Root)
+			}
+			if v := p.Eval(NewHamilton(0, 0.6, 0, 0.8)); !v.ApproxEquals(zeroH) {
+				t.Errorf("p(0.6i+0.8k) = %v, want 0", v)
+			}
+		}
+	}
+	if len(roots) != 2 || spherical != 1 || !isRoot(roots, α) {
+		t.Errorf("Roots(%v) = %v", p, roots)
+	}
+
+	// An isolated root on the sphere of a spherical family, where ±i is a
+	// triple root of the companion polynomial.
+	p = sphere.Mul(linearFactor(NewHamilton(0, 0, 1, 0)))
+	roots = p.Roots()
+	if len(roots) != 1 || !roots[0].Spherical || !roots[0].Root.ApproxEquals(NewHamilton(0, 1, 0, 0)) {
+		t.Errorf("Roots(%v) = %v, want the spherical root i", p, roots)
+	}
+
+	// A real root.
+	p = linearFactor(NewHamilton(-2, 0, 0, 0)).Mul(linearFactor(β))
+	if roots = p.Roots(); !isRoot(roots, NewHamilton(-2, 0, 0, 0)) || !isRoot(roots, β) {
+		t.Errorf("Roots(%v) = %v, want -2 and %v", p, roots, β)
+	}
+}
+
+func TestHamiltonPolyRepeatedRoots(t *testing.T) {
+	one, k := NewHamilton(1, 0, 0, 0), NewHamilton(0, 0, 0, 1)
+	sphere := HamiltonPoly{one, new(Hamilton), one}
+	double := linearFactor(NewHamilton(-1, 0, 0, 0)).Mul(linearFactor(NewHamilton(-1, 0, 0, 0)))
+	var tests = []struct {
+		name string
+		p    HamiltonPoly
+		want []HamiltonRoot
+	}{
+		{"(x-2)²", HamiltonPoly{NewHamilton(4, 0, 0, 0), NewHamilton(-4, 0, 0, 0), one},
+			[]HamiltonRoot{{Root: NewHamilton(2, 0, 0, 0)}}},
+		{"(x²+1)²", sphere.Mul(sphere),
+			[]HamiltonRoot{{Root: NewHamilton(0, 1, 0, 0), Spherical: true}}},
+		{"(x+1)²(x-k)", double.Mul(linearFactor(k)),
+			[]HamiltonRoot{{Root: NewHamilton(-1, 0, 0, 0)}, {Root: k}}},
+		{"(x-α)²", linearFactor(NewHamilton(1, 0, 2, 0)).Mul(linearFactor(NewHamilton(1, 0, 2, 0))),
+			[]HamiltonRoot{{Root: NewHamilton(1, 0, 2, 0)}}},
+		{"(x²+1)²(x-k)²", sphere.Mul(sphere).Mul(linearFactor(k)).Mul(linearFactor(k)),
+			[]HamiltonRoot{{Root: NewHamilton(0, 1, 0, 0), Spherical: true}}},
+	}
+	for _, tt := range tests {
+		roots := tt.p.Roots()
+		if len(roots) != len(tt.want) {
+			t.Errorf("Roots(%s) = %v, want %v", tt.name, roots, tt.want)
+			continue
+		}
+		for _, w := range tt.want {
+			found := false
+			for _, r := range roots {
+				if r.Spherical == w.Spherical && r.Root.ApproxEquals(w.Root) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Roots(%s) = %v, want %v", tt.name, roots, tt.want)
+			}
+		}
+	}
+}
+
+func TestQuadraticRoots(t *testing.T) {
+	// x² + 1 vanishes on the unit sphere of pure quaternions.
+	roots := QuadraticRoots(new(Hamilton), NewHamilton(1, 0, 0, 0))
+	if len(roots) != 1 || !roots[0].Spherical {
+		t.Errorf("QuadraticRoots(0, 1) = %v, want a spherical root", roots)
+	}
+	α, β := NewHamilton(0, 1, 1, 0), NewHamilton(2, 0, 0, 1)
+	p := linearFactor(β).Mul(linearFactor(α))
+	roots = QuadraticRoots(p[1], p[0])
+	if len(roots) != 2 || !isRoot(roots, α) {
+		t.Errorf("QuadraticRoots = %v, want 2 isolated roots including %v", roots, α)
+	}
+}
+
+func TestHamiltonSylvester(t *testing.T) {
+	a, b, x := NewHamilton(1, 2, 0, -1), NewHamilton(0.5, 0, 1, 1), NewHamilton(-1, 0.5, 2, 3)
+	c := new(Hamilton).Add(new(Hamilton).Mul(a, x), new(Hamilton).Mul(x, b))
+	if got := new(Hamilton).Sylvester(a, b, c); !got.ApproxEquals(x) {
+		t.Errorf("Sylvester(%v, %v, %v) = %v, want %v", a, b, c, got, x)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Sylvester with a similar to -b did not panic")
+		}
+	}()
+	new(Hamilton).Sylvester(NewHamilton(0, 1, 0, 0), NewHamilton(0, 0, -1, 0), c)
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// A QFTSide selects the side of the signal on which the exponential kernel
+// of a quaternion Fourier transform multiplies.
+type QFTSide int
+
+// The variants of the quaternion Fourier transform. With θ = 2π*u*x/N, the
+// left transform sums exp(-μθ)*f[x], and the right transform sums
+// f[x]*exp(-μθ). The two-sided transform of a grid sums
+// exp(-μα)*f[x][y]*exp(-μβ), with α = 2π*u*x/M over the rows and
+// β = 2π*v*y/N over the columns.
+const (
+	QFTLeft QFTSide = iota
+	QFTRight
+	QFTTwoSided
+)
+
+// qftBasis returns the unit vector μ of the axis of a quaternion Fourier
+// transform, along with unit vectors ν and λ such that μ, ν, λ is a
+// right-handed orthonormal basis (so that μ*ν = λ). If the axis is not a
+// pure unit quaternion, then qftBasis panics.
+func qftBasis(axis *Hamilton) (μ, ν, λ Vec3) {
+	a, _, _, _ := axis.Cartesian()
+	μ = axis.Vec()
+	if notEquals(a, 0) || notEquals(μ.Norm(), 1) {
+		panic("axis is not a pure unit quaternion")
+	}
+	e := Vec3{1, 0, 0}
+	if math.Abs(μ[0]) > 0.5 {
+		e = Vec3{0, 1, 0}
+	}
+	ν = μ.Cross(e).Unit()
+	return μ, ν, μ.Cross(ν)
+}
+
+// symplecticSplit returns the complex values c1 and c2, in the plane of μ, of
+// the symplectic decomposition z = c1 + c2*ν, or z = c1 + ν*c2 if νLeft is
+// true.
+func symplecticSplit(z *Hamilton, μ, ν, λ Vec3, νLeft bool) (c1, c2 complex128) {
+	a, _, _, _ := z.Cartesian()
+	v := z.Vec()
+	c1 = complex(a, v.Dot(μ))
+	if νLeft {
+		return c1, complex(v.Dot(ν), -v.Dot(λ))
+	}
+	return c1, complex(v.Dot(ν), v.Dot(λ))
+}
+
+// symplecticJoin returns the Hamilton value c1 + c2*ν, or c1 + ν*c2 if νLeft is
+// true, with the complex values c1 and c2 in the plane of μ.
+func symplecticJoin(c1, c2 complex128, μ, ν, λ Vec3, νLeft bool) Hamilton {
+	d := imag(c2)
+	if νLeft {
+		d = -d
+	}
+	v := μ.Scale(imag(c1)).Add(ν.Scale(real(c2))).Add(λ.Scale(d))
+	return *NewHamilton(real(c1), v[0], v[1], v[2])
+}
+
+// QFT returns the discrete quaternion Fourier transform of f with the pure
+// unit axis μ, on the given side. Only the left and right transforms are
+// defined for a one-dimensional signal. The transform is computed with two
+// complex FFTs of the symplectic decomposition of f. If μ is not a pure unit
+// quaternion, or side is QFTTwoSided, then QFT panics.
+func QFT(f []Hamilton, μ *Hamilton, side QFTSide) []Hamilton {
+	return qft1(f, μ, side, false)
+}
+
+// InverseQFT returns the inverse of QFT, so that InverseQFT(QFT(f, μ, side),
+// μ, side) equals f.
+func InverseQFT(f []Hamilton, μ *Hamilton, side QFTSide) []Hamilton {
+	return qft1(f, μ, side, true)
+}
+
+// qft1 returns the forward or inverse quaternion Fourier transform of f.
+func qft1(f []Hamilton, axis *Hamilton, side QFTSide, inverse bool) []Hamilton {
+	if side == QFTTwoSided {
+		panic("two-sided transform of a one-dimensional signal")
+	}
+	μ, ν, λ := qftBasis(axis)
+	νLeft := side == QFTRight
+	n := len(f)
+	c1, c2 := make([]complex128, n), make([]complex128, n)
+	for k := range f {
+		c1[k], c2[k] = symplecticSplit(&f[k], μ, ν, λ, νLeft)
+	}
+	c1, c2 = fft(c1, inverse), fft(c2, inverse)
+	g := make([]Hamilton, n)
+	s := 1.0
+	if inverse {
+		s = 1 / float64(n)
+	}
+	for k := range g {
+		g[k] = symplecticJoin(c1[k]*complex(s, 0), c2[k]*complex(s, 0), μ, ν, λ, νLeft)
+	}
+	return g
+}
+
+// QFT2 returns the discrete quaternion Fourier transform of the grid f, with
+// rows indexed by x and columns by y, with the pure unit axis μ, on the given
+// side. If μ is not a pure unit quaternion, or the rows of f have different
+// lengths, then QFT2 panics.
+func QFT2(f [][]Hamilton, μ *Hamilton, side QFTSide) [][]Hamilton {
+	return qft2(f, μ, side, false)
+}
+
+// InverseQFT2 returns the inverse of QFT2, so that InverseQFT2(QFT2(f, μ,
+// side), μ, side) equals f.
+func InverseQFT2(f [][]Hamilton, μ *Hamilton, side QFTSide) [][]Hamilton {
+	return qft2(f, μ, side, true)
+}
+
+// qft2 returns the forward or inverse quaternion Fourier transform of the
+// grid f. The left and right transforms use the decompositions f = c1 + c2*ν
+// and f = c1 + ν*c2. Since ν anticommutes with μ, the two-sided transform of
+// f = c1 + c2*ν is C1[u][v] + C2[u][-v]*ν, with C1 and C2 the complex
+// transforms of c1 and c2.
+func qft2(f [][]Hamilton, axis *Hamilton, side QFTSide, inverse bool) [][]Hamilton {
+	μ, ν, λ := qftBasis(axis)
+	νLeft := side == QFTRight
+	m := len(f)
+	if m == 0 {
+		return nil
+	}
+	n := len(f[0])
+	c1, c2 := make([][]complex128, m), make([][]complex128, m)
+	for x := range f {
+		if len(f[x]) != n {
+			panic("rows have different lengths")
+		}
+		c1[x], c2[x] = make([]complex128, n), make([]complex128, n)
+		for y := range f[x] {
+			c1[x][y], c2[x][y] = symplecticSplit(&f[x][y], μ, ν, λ, νLeft)
+		}
+	}
+	c1, c2 = fft2(c1, inverse), fft2(c2, inverse)
+	s := complex(1, 0)
+	if inverse {
+		s = complex(1/float64(m*n), 0)
+	}
+	g := make([][]Hamilton, m)
+	for u := range g {
+		g[u] = make([]Hamilton, n)
+		for v := range g[u] {
+			w := v
+			if side == QFTTwoSided {
+				w = (n - v) % n
+			}
+			g[u][v] = symplecticJoin(c1[u][v]*s, c2[u][w]*s, μ, ν, λ, νLeft)
+		}
+	}
+	return g
+}
+
+// fft2 returns the unnormalized two-dimensional discrete Fourier transform of
+// the grid x.
+func fft2(x [][]complex128, inverse bool) [][]complex128 {
+	m, n := len(x), len(x[0])
+	y := make([][]complex128, m)
+	for r := range x {
+		y[r] = fft(x[r], inverse)
+	}
+	col := make([]complex128, m)
+	for c := 0; c < n; c++ {
+		for r := range y {
+			col[r] = y[r][c]
+		}
+		t := fft(col, inverse)
+		for r := range y {
+			y[r][c] = t[r]
+		}
+	}
+	return y
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+// qftKernel returns exp(-μθ) for a pure unit μ.
+func qftKernel(μ *Hamilton, θ float64) *Hamilton {
+	s, c := math.Sincos(-θ)
+	v := μ.Vec().Scale(s)
+	return NewHamilton(c, v[0], v[1], v[2])
+}
+
+// naiveQFT2 returns the quaternion Fourier transform of the grid f by direct
+// summation.
+func naiveQFT2(f [][]Hamilton, μ *Hamilton, side QFTSide) [][]Hamilton {
+	m, n := len(f), len(f[0])
+	g := make([][]Hamilton, m)
+	for u := range g {
+		g[u] = make([]Hamilton, n)
+		for v := range g[u] {
+			for x := range f {
+				for y := range f[x] {
+					α := 2 * math.Pi * float64(u*x) / float64(m)
+					β := 2 * math.Pi * float64(v*y) / float64(n)
+					var p *Hamilton
+					switch side {
+					case QFTLeft:
+						p = new(Hamilton).Mul(qftKernel(μ, α+β), &f[x][y])
+					case QFTRight:
+						p = new(Hamilton).Mul(&f[x][y], qftKernel(μ, α+β))
+					default:
+						p = new(Hamilton).Mul(qftKernel(μ, α), &f[x][y])
+						p.Mul(p, qftKernel(μ, β))
+					}
+					g[u][v].Add(&g[u][v], p)
+				}
+			}
+		}
+	}
+	return g
+}
+
+// testGrid returns an m×n grid of pure quaternions.
+func testGrid(m, n int) [][]Hamilton {
+	f := make([][]Hamilton, m)
+	for x := range f {
+		f[x] = make([]Hamilton, n)
+		for y := range f[x] {
+			f[x][y] = *NewHamilton(0, math.Sin(float64(x+2*y)), float64((x*y)%3), math.Cos(float64(x)))
+		}
+	}
+	return f
+}
+
+// closeGrid returns true if the entries of f and g are close.
+func closeGrid(f, g [][]Hamilton) bool {
+	for x := range f {
+		for y := range f[x] {
+			if new(Hamilton).Sub(&f[x][y], &g[x][y]).Quad() > 1e-16 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestQFT2(t *testing.T) {
+	μ := NewHamilton(0, 1, 1, 1)
+	μ.Normalize(μ)
+	for _, side := range []QFTSide{QFTLeft, QFTRight, QFTTwoSided} {
+		for _, dims := range [][2]int{{4, 4}, {3, 5}} {
+			f := testGrid(dims[0], dims[1])
+			g := QFT2(f, μ, side)
+			if want := naiveQFT2(f, μ, side); !closeGrid(g, want) {
+				t.Errorf("QFT2(side %d, %v) = %v, want %v", side, dims, g, want)
+			}
+			if h := InverseQFT2(g, μ, side); !closeGrid(h, f) {
+				t.Errorf("InverseQFT2(QFT2(f)) = %v, want %v", h, f)
+			}
+		}
+	}
+}
+
+func TestQFT(t *testing.T) {
+	μ := NewHamilton(0, 0, 0.6, 0.8)
+	for _, side := range []QFTSide{QFTLeft, QFTRight} {
+		f := testGrid(1, 6)
+		g := QFT(f[0], μ, side)
+		want := naiveQFT2(f, μ, side)
+		if !closeGrid([][]Hamilton{g}, want) {
+			t.Errorf("QFT(side %d) = %v, want %v", side, g, want[0])
+		}
+		if h := InverseQFT(g, μ, side); !closeGrid([][]Hamilton{h}, f) {
+			t.Errorf("InverseQFT(QFT(f)) = %v, want %v", h, f[0])
+		}
+	}
+}
+
+func TestQFTPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("QFT with an axis that is not pure did not panic")
+		}
+	}()
+	QFT(make([]Hamilton, 4), NewHamilton(1, 0, 0, 0), QFTLeft)
+}
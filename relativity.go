@@ -0,0 +1,128 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// The functions in this file view a Macfarlane value t + xs + yt + zu as the
+// four-vector with time t and position (x, y, z), in units with c = 1. The
+// quadrance is then the Minkowski quadrance t² - x² - y² - z², with signature
+// (+, -, -, -). Boosts are active: they change the four-vectors, not the
+// frame of reference.
+
+// FourVectorMacfarlane returns the Macfarlane value of the four-vector with
+// time t and position x.
+func FourVectorMacfarlane(t float64, x Vec3) *Macfarlane {
+	return NewMacfarlane(t, x[0], x[1], x[2])
+}
+
+// FourVector returns the time and position of the four-vector z.
+func (z *Macfarlane) FourVector() (t float64, x Vec3) {
+	return z[0], Vec3{z[1], z[2], z[3]}
+}
+
+// lorentzMacfarlane sets z equal to the image of x under the unit
+// biquaternion l, and returns z.
+func (z *Macfarlane) lorentzMacfarlane(l *Biquaternion, x *Macfarlane) *Macfarlane {
+	b := new(Biquaternion).FromMacfarlane(x)
+	y, _ := b.Lorentz(l, b).Macfarlane()
+	return z.Copy(y)
+}
+
+// Boost sets z equal to the four-vector x boosted by the rapidity vector ξ,
+// and returns z. A particle at rest is boosted to the velocity
+// VelocityOf(ξ).
+func (z *Macfarlane) Boost(x *Macfarlane, ξ Vec3) *Macfarlane {
+	return z.lorentzMacfarlane(BoostBiquaternion(ξ), x)
+}
+
+// BoostVelocity sets z equal to the four-vector x boosted by the velocity v,
+// and returns z. If |v| ≥ 1, then BoostVelocity panics.
+func (z *Macfarlane) BoostVelocity(x *Macfarlane, v Vec3) *Macfarlane {
+	return z.Boost(x, RapidityOf(v))
+}
+
+// RapidityOf returns the rapidity vector of the velocity v, which points
+// along v with length atanh(|v|). If |v| ≥ 1, then RapidityOf panics.
+func RapidityOf(v Vec3) Vec3 {
+	s := v.Norm()
+	if s >= 1 {
+		panic("speed is not less than the speed of light")
+	}
+	if s == 0 {
+		return Vec3{}
+	}
+	return v.Scale(math.Atanh(s) / s)
+}
+
+// VelocityOf returns the velocity of the rapidity vector ξ, which points along
+// ξ with length tanh(|ξ|).
+func VelocityOf(ξ Vec3) Vec3 {
+	φ := ξ.Norm()
+	if φ == 0 {
+		return Vec3{}
+	}
+	return ξ.Scale(math.Tanh(φ) / φ)
+}
+
+// LorentzFactor returns the Lorentz factor 1/√(1 - |v|²) of the velocity v.
+func LorentzFactor(v Vec3) float64 {
+	return 1 / math.Sqrt(1-v.Dot(v))
+}
+
+// Velocity returns the velocity x/t of the four-vector z.
+func (z *Macfarlane) Velocity() Vec3 {
+	t, x := z.FourVector()
+	return x.Scale(1 / t)
+}
+
+// Rapidity returns the rapidity vector of the velocity of the four-vector z.
+// Its length equals the ξ returned by Curv for a timelike z. If z is not
+// timelike, then its speed is not less than 1 and Rapidity panics.
+func (z *Macfarlane) Rapidity() Vec3 {
+	return RapidityOf(z.Velocity())
+}
+
+// ProperTime returns the proper time √(t² - |x|²) elapsed along a straight
+// worldline from the origin to the event z. If z is spacelike, then ProperTime
+// returns NaN.
+func (z *Macfarlane) ProperTime() float64 {
+	return math.Sqrt(z.Quad())
+}
+
+// WorldlineProperTime returns the proper time elapsed along the worldline
+// made of straight segments between consecutive events.
+func WorldlineProperTime(events []*Macfarlane) float64 {
+	var τ float64
+	for n := 1; n < len(events); n++ {
+		τ += new(Macfarlane).Sub(events[n], events[n-1]).ProperTime()
+	}
+	return τ
+}
+
+// ComposeVelocities returns the relativistic composition of the velocities u
+// and v: the velocity in the lab of a particle that moves with velocity v in a
+// frame that moves with velocity u. It equals the velocity of a particle at
+// rest after boosting first by v and then by u.
+func ComposeVelocities(u, v Vec3) Vec3 {
+	γ := LorentzFactor(u)
+	w := u.Add(v.Scale(1 / γ)).Add(u.Scale(γ / (1 + γ) * u.Dot(v)))
+	return w.Scale(1 / (1 + u.Dot(v)))
+}
+
+// ThomasWignerRotation returns the unit Hamilton value of the Thomas-Wigner
+// rotation R of the velocities u and v, defined by
+//
+//	Boost(u) Boost(v) = Boost(ComposeVelocities(u, v)) R
+//
+// with each boost given by its velocity. The axis of R is parallel to
+// Cross(v, u).
+func ThomasWignerRotation(u, v Vec3) *Hamilton {
+	w := ComposeVelocities(u, v)
+	l := BoostBiquaternion(RapidityOf(u))
+	l.Mul(l, BoostBiquaternion(RapidityOf(v)))
+	l.Mul(new(Biquaternion).Inv(BoostBiquaternion(RapidityOf(w))), l)
+	r, _ := l.Hamilton()
+	return positive(r.Normalize(r))
+}
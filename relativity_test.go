@@ -0,0 +1,103 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMacfarlaneBoost(t *testing.T) {
+	ξ := Vec3{0.2, 0.7, -0.4}
+	x := FourVectorMacfarlane(1.5, Vec3{0.3, -2, 1})
+	y := new(Macfarlane).Boost(x, ξ)
+	if notEquals(y.Quad(), x.Quad()) {
+		t.Errorf("Quad changed from %v to %v", x.Quad(), y.Quad())
+	}
+	rest := new(Macfarlane).Boost(FourVectorMacfarlane(1, Vec3{}), ξ)
+	if v := rest.Velocity(); !closeVec3(v, VelocityOf(ξ), 1e-12) {
+		t.Errorf("boosted rest velocity = %v, want %v", v, VelocityOf(ξ))
+	}
+	if r := rest.Rapidity(); !closeVec3(r, ξ, 1e-12) {
+		t.Errorf("Rapidity = %v, want %v", r, ξ)
+	}
+	if _, φ, _, _, sign := rest.Curv(); sign != 1 || notEquals(φ, ξ.Norm()) {
+		t.Errorf("Curv rapidity = %v, want %v", φ, ξ.Norm())
+	}
+	if τ := rest.ProperTime(); notEquals(τ, 1) {
+		t.Errorf("ProperTime = %v, want 1", τ)
+	}
+	back := new(Macfarlane).BoostVelocity(y, VelocityOf(ξ).Scale(-1))
+	if !back.Equals(x) {
+		t.Errorf("inverse boost gives %v, want %v", back, x)
+	}
+}
+
+func TestComposeVelocities(t *testing.T) {
+	u, v := Vec3{0.6, 0, 0}, Vec3{0, 0.7, 0.1}
+	w := ComposeVelocities(u, v)
+	if w.Norm() >= 1 {
+		t.Errorf("ComposeVelocities(%v, %v) = %v is faster than light", u, v, w)
+	}
+	x := FourVectorMacfarlane(1, Vec3{})
+	x.BoostVelocity(x, v)
+	x.BoostVelocity(x, u)
+	if got := x.Velocity(); !closeVec3(got, w, 1e-12) {
+		t.Errorf("velocity after two boosts = %v, want %v", got, w)
+	}
+	// Collinear velocities compose with the usual formula.
+	w = ComposeVelocities(Vec3{0.5, 0, 0}, Vec3{0.5, 0, 0})
+	if !closeVec3(w, Vec3{0.8, 0, 0}, 1e-12) {
+		t.Errorf("ComposeVelocities of collinear velocities = %v, want 0.8", w)
+	}
+}
+
+func TestThomasWignerRotation(t *testing.T) {
+	u, v := Vec3{0.8, 0, 0}, Vec3{0, 0.6, 0}
+	r := ThomasWignerRotation(u, v)
+	axis, θ := r.AxisAngle()
+	if !closeVec3(axis, v.Cross(u).Unit(), 1e-12) {
+		t.Errorf("Thomas-Wigner axis = %v, want %v", axis, v.Cross(u).Unit())
+	}
+	// For perpendicular velocities, cos θ = (γu + γv)/(1 + γu γv).
+	γu, γv := LorentzFactor(u), LorentzFactor(v)
+	if notEquals(math.Cos(θ), (γu+γv)/(1+γu*γv)) {
+		t.Errorf("Thomas-Wigner angle = %v, want %v", θ, math.Acos((γu+γv)/(1+γu*γv)))
+	}
+	// Both sides of the defining relation act equally on a four-vector.
+	x := FourVectorMacfarlane(2, Vec3{0.1, -0.5, 0.3})
+	lhs := new(Macfarlane).BoostVelocity(x, v)
+	lhs.BoostVelocity(lhs, u)
+	t0, p := x.FourVector()
+	rhs := FourVectorMacfarlane(t0, r.Rotate(p))
+	rhs.BoostVelocity(rhs, ComposeVelocities(u, v))
+	if !lhs.Equals(rhs) {
+		t.Errorf("Boost(u) Boost(v) x = %v, want %v", lhs, rhs)
+	}
+	if r := ThomasWignerRotation(u, u.Scale(0.5)); !r.ApproxEquals(oneH) {
+		t.Errorf("collinear Thomas-Wigner rotation = %v, want 1", r)
+	}
+}
+
+func TestWorldlineProperTime(t *testing.T) {
+	// The twin paradox: the travelling twin ages less.
+	v := 0.6
+	events := []*Macfarlane{
+		FourVectorMacfarlane(0, Vec3{}),
+		FourVectorMacfarlane(5, Vec3{5 * v, 0, 0}),
+		FourVectorMacfarlane(10, Vec3{}),
+	}
+	if τ := WorldlineProperTime(events); notEquals(τ, 8) {
+		t.Errorf("WorldlineProperTime = %v, want 8", τ)
+	}
+}
+
+func TestMacfarlaneRapidityPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Rapidity of a spacelike four-vector did not panic")
+		}
+	}()
+	FourVectorMacfarlane(1, Vec3{2, 0, 0}).Rapidity()
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+// A RigidBody is the state of a rigid body. Position and Velocity are the
+// position and velocity of the center of mass in the world frame, Q is the
+// unit Hamilton value that rotates body vectors into the world frame, and
+// AngularVelocity is the angular velocity in the body frame. Inertia is the
+// inertia tensor about the center of mass in the body frame, which must be
+// symmetric and positive definite.
+type RigidBody struct {
+	Mass               float64
+	Inertia            Mat3
+	Position, Velocity Vec3
+	Q                  *Hamilton
+	AngularVelocity    Vec3
+}
+
+// Momentum returns the linear momentum of b.
+func (b *RigidBody) Momentum() Vec3 {
+	return b.Velocity.Scale(b.Mass)
+}
+
+// AngularMomentum returns the angular momentum of b about its center of mass,
+// in the world frame.
+func (b *RigidBody) AngularMomentum() Vec3 {
+	return b.Q.Rotate(b.Inertia.MulVec(b.AngularVelocity))
+}
+
+// KineticEnergy returns the translational plus the rotational kinetic energy
+// of b.
+func (b *RigidBody) KineticEnergy() float64 {
+	ω := b.AngularVelocity
+	return (b.Mass*b.Velocity.Dot(b.Velocity) + ω.Dot(b.Inertia.MulVec(ω))) / 2
+}
+
+// AngularAcceleration returns the derivative of the body angular velocity of
+// b under the torque τ in the world frame, from the Euler equations
+// I dω/dt = τ - ω×Iω, with τ and ω in the body frame.
+func (b *RigidBody) AngularAcceleration(τ Vec3) Vec3 {
+	ω := b.AngularVelocity
+	τ = new(Hamilton).Conj(b.Q).Rotate(τ)
+	return b.Inertia.Inv().MulVec(τ.Sub(ω.Cross(b.Inertia.MulVec(ω))))
+}
+
+// principalAxes returns the principal moments of inertia of b and the unit
+// principal axes in the body frame.
+func (b *RigidBody) principalAxes() (moments Vec3, axes [3]Vec3) {
+	a := make([][]float64, 3)
+	for i := range a {
+		a[i] = b.Inertia[i][:]
+	}
+	vals, vecs := symEigen(a)
+	for k := 0; k < 3; k++ {
+		moments[k] = vals[k]
+		axes[k] = Vec3{vecs[0][k], vecs[1][k], vecs[2][k]}
+	}
+	return
+}
+
+// Step advances b by the time step dt under the constant force f and torque τ,
+// both in the world frame, with a second-order symplectic splitting method.
+// The translation is a velocity Verlet step. The rotation kicks the angular
+// momentum by half of the torque impulse, then applies the exact flows of the
+// free rotations about the principal axes in the symmetric sequence
+// 1, 2, 3, 2, 1, and then applies the other half of the impulse. Each of these
+// flows is a rotation, so that Q stays a unit, the length of the body angular
+// momentum is exact, and without torque the world angular momentum is exact
+// up to rounding while the energy error stays bounded.
+func (b *RigidBody) Step(f, τ Vec3, dt float64) {
+	a := f.Scale(1 / b.Mass)
+	b.Velocity = b.Velocity.Add(a.Scale(dt / 2))
+	b.Position = b.Position.Add(b.Velocity.Scale(dt))
+	b.Velocity = b.Velocity.Add(a.Scale(dt / 2))
+
+	moments, axes := b.principalAxes()
+	kick := new(Hamilton).Conj(b.Q).Rotate(τ.Scale(dt / 2))
+	l := b.Inertia.MulVec(b.AngularVelocity).Add(kick)
+	q := new(Hamilton).Copy(b.Q)
+	free := func(k int, h float64) {
+		r := RotationHamilton(axes[k], l.Dot(axes[k])/moments[k]*h)
+		q.Mul(q, r)
+		l = new(Hamilton).Conj(r).Rotate(l)
+	}
+	free(0, dt/2)
+	free(1, dt/2)
+	free(2, dt)
+	free(1, dt/2)
+	free(0, dt/2)
+	b.Q = q.Normalize(q)
+	kick = new(Hamilton).Conj(b.Q).Rotate(τ.Scale(dt / 2))
+	b.AngularVelocity = b.Inertia.Inv().MulVec(l.Add(kick))
+}
+
+// A Wrench returns the force and the torque, both in the world frame, on the
+// rigid body b at the time t.
+type Wrench func(t float64, b *RigidBody) (f, τ Vec3)
+
+// Simulate advances b by n steps of size dt from the time t0, with the force
+// and torque given by w at the start of each step, or with none if w is nil.
+// It returns the attitudes of b at the start and after each step.
+func (b *RigidBody) Simulate(w Wrench, t0, dt float64, n int) AttitudeSeries {
+	s := make(AttitudeSeries, n+1)
+	s[0] = AttitudeSample{t0, new(Hamilton).Copy(b.Q)}
+	for k := 1; k <= n; k++ {
+		var f, τ Vec3
+		if w != nil {
+			f, τ = w(t0+float64(k-1)*dt, b)
+		}
+		b.Step(f, τ, dt)
+		s[k] = AttitudeSample{t0 + float64(k)*dt, new(Hamilton).Copy(b.Q)}
+	}
+	return s
+}
+
+// BoxInertia returns the inertia tensor of a box of mass m with edges a, b,
+// and c along the x, y, and z axes.
+func BoxInertia(m, a, b, c float64) Mat3 {
+	return Mat3{
+		{m * (b*b + c*c) / 12, 0, 0},
+		{0, m * (a*a + c*c) / 12, 0},
+		{0, 0, m * (a*a + b*b) / 12},
+	}
+}
+
+// CylinderInertia returns the inertia tensor of a cylinder of mass m, radius
+// r, and height h along the z axis.
+func CylinderInertia(m, r, h float64) Mat3 {
+	s := m * (3*r*r + h*h) / 12
+	return Mat3{{s, 0, 0}, {0, s, 0}, {0, 0, m * r * r / 2}}
+}
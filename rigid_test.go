@@ -0,0 +1,135 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+// testRigidBody returns a box-shaped rigid body with distinct principal
+// moments, spinning mostly about the given body axis.
+func testRigidBody(axis int) *RigidBody {
+	ω := Vec3{0.01, 0.01, 0.01}
+	ω[axis] = 4
+	return &RigidBody{
+		Mass:            2,
+		Inertia:         BoxInertia(2, 1, 2, 3),
+		Q:               NewHamilton(1, 0, 0, 0),
+		AngularVelocity: ω,
+	}
+}
+
+func TestRigidBodyIntermediateAxis(t *testing.T) {
+	// The y axis of the box is its intermediate principal axis, so that a
+	// spin about it flips over repeatedly (the Dzhanibekov effect), while
+	// spins about the other axes are stable.
+	for axis, flips := range []bool{false, true, false} {
+		b := testRigidBody(axis)
+		l0, e0 := b.AngularMomentum(), b.KineticEnergy()
+		flipped := false
+		for k := 0; k < 20000; k++ {
+			b.Step(Vec3{}, Vec3{}, 1e-3)
+			if b.AngularVelocity[axis] < 0 {
+				flipped = true
+			}
+		}
+		if flipped != flips {
+			t.Errorf("axis %d: flipped = %v, want %v", axis, flipped, flips)
+		}
+		if d := b.AngularMomentum().Sub(l0).Norm(); d > 1e-10*l0.Norm() {
+			t.Errorf("axis %d: angular momentum changed by %v", axis, d)
+		}
+		if d := math.Abs(b.KineticEnergy() - e0); d > 1e-4*e0 {
+			t.Errorf("axis %d: energy changed by %v", axis, d)
+		}
+		if notEquals(b.Q.Quad(), 1) {
+			t.Errorf("axis %d: attitude %v is not a unit", axis, b.Q)
+		}
+	}
+}
+
+func TestRigidBodyNonDiagonalInertia(t *testing.T) {
+	// A body whose body frame is rotated by r, so that its inertia tensor is
+	// not diagonal, has the attitude Q*Conj(r).
+	r := RotationHamilton(Vec3{1, 2, -1}, 0.8)
+	a := testRigidBody(1)
+	b := &RigidBody{Mass: a.Mass, Q: new(Hamilton).Conj(r), AngularVelocity: r.Rotate(a.AngularVelocity)}
+	for i := 0; i < 3; i++ {
+		var e Vec3
+		e[i] = 1
+		b.Inertia[i] = r.Rotate(a.Inertia.MulVec(new(Hamilton).Conj(r).Rotate(e)))
+	}
+	b.Inertia = b.Inertia.Transpose()
+	for k := 0; k < 1000; k++ {
+		a.Step(Vec3{}, Vec3{}, 1e-3)
+		b.Step(Vec3{}, Vec3{}, 1e-3)
+	}
+	if d := angleBetweenRotations(a.Q, new(Hamilton).Mul(b.Q, r)); d > 1e-9 {
+		t.Errorf("attitudes differ by %v", d)
+	}
+}
+
+func TestRigidBodyConvergence(t *testing.T) {
+	run := func(n int) *Hamilton {
+		b := testRigidBody(1)
+		b.AngularVelocity = Vec3{1, 2, 0.5}
+		for k := 0; k < n; k++ {
+			b.Step(Vec3{}, Vec3{}, 1/float64(n))
+		}
+		return b.Q
+	}
+	want := run(8192)
+	e1 := angleBetweenRotations(run(64), want)
+	e2 := angleBetweenRotations(run(128), want)
+	if order := math.Log2(e1 / e2); math.Abs(order-2) > 0.2 {
+		t.Errorf("observed order %v, want 2 (errors %v, %v)", order, e1, e2)
+	}
+}
+
+func TestRigidBodyForceTorque(t *testing.T) {
+	b := &RigidBody{
+		Mass:     2,
+		Inertia:  CylinderInertia(2, 0.5, 1),
+		Position: Vec3{1, 0, 0},
+		Velocity: Vec3{0, 1, 0},
+		Q:        RotationHamilton(Vec3{1, 1, 0}, 0.4),
+	}
+	f := Vec3{0, 0, -4}
+	τ := Vec3{0.1, -0.2, 0.3}
+	w := func(t float64, b *RigidBody) (Vec3, Vec3) {
+		return f, τ
+	}
+	s := b.Simulate(w, 0, 0.01, 200)
+	if len(s) != 201 || notEquals(s[200].Time, 2) {
+		t.Fatalf("Simulate returned %d samples ending at %v", len(s), s[len(s)-1].Time)
+	}
+	// Constant acceleration gives a parabola.
+	want := Vec3{1, 2, -4}
+	if d := b.Position.Sub(want).Norm(); d > delta {
+		t.Errorf("Position = %v, want %v", b.Position, want)
+	}
+	// Constant torque gives a linear angular momentum.
+	if d := b.AngularMomentum().Sub(τ.Scale(2)).Norm(); d > delta {
+		t.Errorf("AngularMomentum = %v, want %v", b.AngularMomentum(), τ.Scale(2))
+	}
+}
+
+func TestRigidBodyAngularAcceleration(t *testing.T) {
+	b := testRigidBody(0)
+	b.AngularVelocity = Vec3{1, -2, 3}
+	b.Q = RotationHamilton(Vec3{0, 0, 1}, math.Pi/2)
+	m := b.Inertia
+	ω := b.AngularVelocity
+	// The torque is along the world y axis, which is the body x axis.
+	got := b.AngularAcceleration(Vec3{0, 1, 0})
+	want := Vec3{
+		(1 + (m[1][1]-m[2][2])*ω[1]*ω[2]) / m[0][0],
+		(m[2][2] - m[0][0]) * ω[2] * ω[0] / m[1][1],
+		(m[0][0] - m[1][1]) * ω[0] * ω[1] / m[2][2],
+	}
+	if d := got.Sub(want).Norm(); d > delta {
+		t.Errorf("AngularAcceleration = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// An AttitudeSample is a unit Hamilton value Q measured at time Time (in
+// seconds).
+type AttitudeSample struct {
+	Time float64
+	Q    *Hamilton
+}
+
+// An AttitudeSeries is a sequence of attitude samples with increasing times.
+// The filters on an AttitudeSeries work on the sphere of unit Hamilton values
+// instead of on their components, so that their results are always units.
+type AttitudeSeries []AttitudeSample
+
+// checkTimes panics if the times of s are not increasing.
+func (s AttitudeSeries) checkTimes() {
+	for n := 1; n < len(s); n++ {
+		if s[n].Time <= s[n-1].Time {
+			panic("times are not increasing")
+		}
+	}
+}
+
+// Unwrap returns a copy of s in which the sign of each value is chosen so
+// that consecutive values lie in the same hemisphere. Since q and -q are the
+// same rotation, this removes the jumps between them without changing the
+// attitudes.
+func (s AttitudeSeries) Unwrap() AttitudeSeries {
+	u := make(AttitudeSeries, len(s))
+	for n, a := range s {
+		q := new(Hamilton).Copy(a.Q)
+		if n > 0 && dotHamilton(u[n-1].Q, q) < 0 {
+			q.Neg(q)
+		}
+		u[n] = AttitudeSample{a.Time, q}
+	}
+	return u
+}
+
+// karcherMean returns the geodesic (Karcher) mean of the unit Hamilton values
+// q, which minimizes the sum of the squared rotation angles to them. It
+// iterates m ↦ BoxPlus(m, δ), with δ the average of BoxMinus(q[n], m).
+func karcherMean(q []*Hamilton) *Hamilton {
+	m := new(Hamilton).Copy(q[0])
+	for iter := 0; iter < 50; iter++ {
+		var δ Vec3
+		for _, p := range q {
+			δ = δ.Add(BoxMinus(p, m))
+		}
+		δ = δ.Scale(1 / float64(len(q)))
+		m = BoxPlus(m, δ)
+		if δ.Norm() < 1e-12 {
+			break
+		}
+	}
+	return m
+}
+
+// MovingAverage returns the centered moving geodesic average of s: each value
+// is replaced with the Karcher mean of the values at most window samples
+// before or after it. Near the ends, the window is truncated. If window is
+// negative, then MovingAverage panics.
+func (s AttitudeSeries) MovingAverage(window int) AttitudeSeries {
+	if window < 0 {
+		panic("window is negative")
+	}
+	u := make(AttitudeSeries, len(s))
+	for n := range s {
+		lo, hi := n-window, n+window+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(s) {
+			hi = len(s)
+		}
+		q := make([]*Hamilton, hi-lo)
+		for k := range q {
+			q[k] = s[lo+k].Q
+		}
+		m := karcherMean(q)
+		if dotHamilton(m, s[n].Q) < 0 {
+			m.Neg(m)
+		}
+		u[n] = AttitudeSample{s[n].Time, m}
+	}
+	return u
+}
+
+// LowPass returns s filtered by a first-order low-pass filter with cutoff
+// frequency fc (in hertz) in the tangent space: each output moves from the
+// previous output towards the current sample along the geodesic between them,
+// by the fraction α = dt/(RC + dt) with RC = 1/(2π*fc). If the times of s are
+// not increasing, then LowPass panics.
+func (s AttitudeSeries) LowPass(fc float64) AttitudeSeries {
+	s.checkTimes()
+	if len(s) == 0 {
+		return nil
+	}
+	rc := 1 / (2 * math.Pi * fc)
+	u := make(AttitudeSeries, len(s))
+	y := new(Hamilton).Copy(s[0].Q)
+	u[0] = AttitudeSample{s[0].Time, new(Hamilton).Copy(y)}
+	for n := 1; n < len(s); n++ {
+		dt := s[n].Time - s[n-1].Time
+		α := dt / (rc + dt)
+		y = BoxPlus(y, BoxMinus(s[n].Q, y).Scale(α))
+		u[n] = AttitudeSample{s[n].Time, new(Hamilton).Copy(y)}
+	}
+	return u
+}
+
+// Resample returns s resampled at the given rate (in hertz), from the time of
+// its first sample up to the time of its last sample, with Slerp between the
+// neighboring samples. If the rate is not positive, or the times of s are not
+// increasing, then Resample panics.
+func (s AttitudeSeries) Resample(rate float64) AttitudeSeries {
+	if !(rate > 0) {
+		panic("rate is not positive")
+	}
+	s.checkTimes()
+	if len(s) == 0 {
+		return nil
+	}
+	t0, t1 := s[0].Time, s[len(s)-1].Time
+	count := int(math.Floor((t1-t0)*rate+1e-9)) + 1
+	u := make(AttitudeSeries, count)
+	k := 0
+	for n := range u {
+		t := t0 + float64(n)/rate
+		for k < len(s)-2 && s[k+1].Time < t {
+			k++
+		}
+		q := new(Hamilton)
+		if len(s) == 1 {
+			q.Copy(s[0].Q)
+		} else {
+			a, b := s[k], s[k+1]
+			q.Slerp(a.Q, b.Q, math.Max(0, math.Min(1, (t-a.Time)/(b.Time-a.Time))))
+		}
+		u[n] = AttitudeSample{t, q}
+	}
+	return u
+}
+
+// RejectOutliers returns s without the samples that would require an angular
+// rate greater than maxRate (in radians per second) from the previous kept
+// sample. The first sample is always kept. If the times of s are not
+// increasing, then RejectOutliers panics.
+func (s AttitudeSeries) RejectOutliers(maxRate float64) AttitudeSeries {
+	s.checkTimes()
+	var u AttitudeSeries
+	for n, a := range s {
+		if n > 0 {
+			last := u[len(u)-1]
+			if angleBetweenRotations(last.Q, a.Q)/(a.Time-last.Time) > maxRate {
+				continue
+			}
+		}
+		u = append(u, AttitudeSample{a.Time, new(Hamilton).Copy(a.Q)})
+	}
+	return u
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"testing"
+)
+
+// spinSeries returns samples at the given times of a rotation about the z
+// axis at the angular rate ω, with the sign of every third value flipped.
+func spinSeries(times []float64, ω float64) AttitudeSeries {
+	s := make(AttitudeSeries, len(times))
+	for n, t := range times {
+		q := RotationHamilton(Vec3{0, 0, 1}, ω*t)
+		if n%3 == 2 {
+			q.Neg(q)
+		}
+		s[n] = AttitudeSample{t, q}
+	}
+	return s
+}
+
+// uniformTimes returns n times spaced by dt, starting at 0.
+func uniformTimes(n int, dt float64) []float64 {
+	t := make([]float64, n)
+	for k := range t {
+		t[k] = float64(k) * dt
+	}
+	return t
+}
+
+func TestAttitudeSeriesUnwrap(t *testing.T) {
+	s := spinSeries(uniformTimes(20, 0.1), 1).Unwrap()
+	for n := 1; n < len(s); n++ {
+		if dotHamilton(s[n-1].Q, s[n].Q) < 0 {
+			t.Errorf("samples %d and %d are in opposite hemispheres", n-1, n)
+		}
+	}
+}
+
+func TestAttitudeSeriesMovingAverage(t *testing.T) {
+	// A uniform rotation is a geodesic, so a centered average leaves it
+	// unchanged away from the ends.
+	s := spinSeries(uniformTimes(20, 0.1), 1)
+	m := s.MovingAverage(2)
+	for n := 2; n < len(s)-2; n++ {
+		if a := angleBetweenRotations(m[n].Q, s[n].Q); a > 1e-9 {
+			t.Errorf("sample %d moved by %v", n, a)
+		}
+	}
+	// Alternating tilts about the x axis average out.
+	for n := range s {
+		tilt := RotationHamilton(Vec3{1, 0, 0}, 0.05*math.Pow(-1, float64(n)))
+		s[n].Q = new(Hamilton).Mul(s[n].Q, tilt)
+	}
+	m = s.MovingAverage(3)
+	want := RotationHamilton(Vec3{0, 0, 1}, 1.0)
+	if a, b := angleBetweenRotations(m[10].Q, want), angleBetweenRotations(s[10].Q, want); a > b/4 {
+		t.Errorf("error after averaging = %v, before = %v", a, b)
+	}
+	for n := range m {
+		if notEquals(m[n].Q.Quad(), 1) {
+			t.Errorf("sample %d is not a unit", n)
+		}
+	}
+}
+
+func TestAttitudeSeriesLowPass(t *testing.T) {
+	// A step from the identity to a fixed rotation converges to the rotation.
+	times := uniformTimes(200, 0.01)
+	target := RotationHamilton(Vec3{1, 2, 3}, 1)
+	s := make(AttitudeSeries, len(times))
+	for n, tm := range times {
+		s[n] = AttitudeSample{tm, target}
+	}
+	s[0].Q = NewHamilton(1, 0, 0, 0)
+	y := s.LowPass(5)
+	if a := angleBetweenRotations(y[1].Q, target); a < 0.1 {
+		t.Errorf("filter responded too quickly: angle %v after one step", a)
+	}
+	if a := angleBetweenRotations(y[len(y)-1].Q, target); a > 1e-6 {
+		t.Errorf("filter did not converge: angle %v", a)
+	}
+}
+
+func TestAttitudeSeriesResample(t *testing.T) {
+	s := spinSeries([]float64{0, 0.13, 0.2, 0.41, 0.5, 0.77, 1}, 2)
+	r := s.Resample(10)
+	if len(r) != 11 {
+		t.Fatalf("Resample returned %d samples, want 11", len(r))
+	}
+	for n, a := range r {
+		if notEquals(a.Time, 0.1*float64(n)) {
+			t.Errorf("time %d = %v, want %v", n, a.Time, 0.1*float64(n))
+		}
+		if d := angleBetweenRotations(a.Q, RotationHamilton(Vec3{0, 0, 1}, 2*a.Time)); d > 1e-9 {
+			t.Errorf("sample %d is off by %v", n, d)
+		}
+	}
+}
+
+func TestAttitudeSeriesRejectOutliers(t *testing.T) {
+	s := spinSeries(uniformTimes(10, 0.1), 1)
+	s[4].Q = RotationHamilton(Vec3{1, 0, 0}, 2)
+	r := s.RejectOutliers(5)
+	if len(r) != 9 {
+		t.Errorf("RejectOutliers kept %d samples, want 9", len(r))
+	}
+	for _, a := range r {
+		if a.Time == s[4].Time {
+			t.Error("outlier was kept")
+		}
+	}
+}
+
+func TestAttitudeSeriesPanics(t *testing.T) {
+	var tests = []struct {
+		name string
+		f    func()
+	}{
+		{"Resample with decreasing times", func() { spinSeries([]float64{0, 1, 0.5}, 1).Resample(10) }},
+		{"Resample with zero rate", func() { spinSeries([]float64{0, 1}, 1).Resample(0) }},
+		{"Resample with negative rate", func() { spinSeries([]float64{0, 1}, 1).Resample(-1) }},
+		{"MovingAverage with negative window", func() { spinSeries([]float64{0, 1}, 1).MovingAverage(-1) }},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.f()
+		}()
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"sort"
+)
+
+// SVD returns the thin singular value decomposition m = u*diag(s)*v* of m,
+// with the singular values s in decreasing order. For an r×c matrix with
+// k = min(r, c), u is r×k and v is c×k, and both have orthonormal columns.
+//
+// SVD uses one-sided Jacobi rotations: each pair of columns is first aligned
+// by a unit quaternion phase on the right, which makes their inner product
+// real, and then orthogonalized by a real Givens rotation.
+func (m HamiltonMatrix) SVD() (u HamiltonMatrix, s []float64, v HamiltonMatrix) {
+	r, c := m.Dims()
+	if r < c {
+		v, s, u = m.ConjTranspose().SVD()
+		return
+	}
+	a := m.Copy()
+	v = IdentityHamiltonMatrix(c)
+	for sweep := 0; sweep < 100; sweep++ {
+		rotated := false
+		for p := 0; p < c; p++ {
+			for q := p + 1; q < c; q++ {
+				α, β := columnQuad(a, p), columnQuad(a, q)
+				γ := columnDot(a, p, q)
+				g := math.Sqrt(γ.Quad())
+				if g <= 1e-15*math.Sqrt(α*β) {
+					continue
+				}
+				rotated = true
+				// Make the inner product of the columns real and positive.
+				phase := new(Hamilton).Conj(γ)
+				phase.Dil(phase, 1/g)
+				scaleColumn(a, q, phase)
+				scaleColumn(v, q, phase)
+				ζ := (β - α) / (2 * g)
+				t := 1 / (math.Abs(ζ) + math.Sqrt(ζ*ζ+1))
+				if ζ < 0 {
+					t = -t
+				}
+				cs := 1 / math.Sqrt(t*t+1)
+				sn := cs * t
+				rotateColumns(a, p, q, cs, sn)
+				rotateColumns(v, p, q, cs, sn)
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+	s = make([]float64, c)
+	for j := range s {
+		s[j] = math.Sqrt(columnQuad(a, j))
+	}
+	order := decreasingOrder(s)
+	u, v = permuteColumns(a, order), permuteColumns(v, order)
+	sorted := make([]float64, c)
+	for j, k := range order {
+		sorted[j] = s[k]
+	}
+	s = sorted
+	for j := range s {
+		if s[j] > 1e-14*s[0] {
+			scaleColumn(u, j, NewHamilton(1/s[j], 0, 0, 0))
+			continue
+		}
+		completeColumn(u, j)
+	}
+	return u, s, v
+}
+
+// HermitianEigen returns the eigenvalues of the Hermitian matrix m in
+// decreasing order, along with a unitary matrix whose columns are the
+// corresponding eigenvectors, so that m = vecs*diag(vals)*vecs*. The
+// eigenvalues of a Hermitian quaternion matrix are real.
+//
+// HermitianEigen uses two-sided Jacobi rotations: each off-diagonal entry is
+// first made real by a diagonal unitary phase, and then eliminated by a real
+// Givens rotation. If m is not Hermitian, then HermitianEigen panics.
+func (m HamiltonMatrix) HermitianEigen() (vals []float64, vecs HamiltonMatrix) {
+	n := m.isSquare()
+	for i := range m {
+		for j := i; j < n; j++ {
+			if !m[i][j].ApproxEquals(new(Hamilton).Conj(m[j][i])) {
+				panic("matrix is not Hermitian")
+			}
+		}
+	}
+	a := m.Copy()
+	v := IdentityHamiltonMatrix(n)
+	for sweep := 0; sweep < 100; sweep++ {
+		var off float64
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += a[p][q].Quad()
+			}
+		}
+		if off < 1e-30 {
+			break
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				g := math.Sqrt(a[p][q].Quad())
+				if g == 0 {
+					continue
+				}
+				// With the phase d = Conj(a[p][q])/|a[p][q]|, the entry
+				// a[p][q]*d is real.
+				d := new(Hamilton).Conj(a[p][q])
+				d.Dil(d, 1/g)
+				scaleColumn(a, q, d)
+				scaleRow(a, q, new(Hamilton).Conj(d))
+				scaleColumn(v, q, d)
+				app, aqq := real(a[p][p][0]), real(a[q][q][0])
+				θ := (aqq - app) / (2 * g)
+				t := 1 / (math.Abs(θ) + math.Sqrt(θ*θ+1))
+				if θ < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				rotateColumns(a, p, q, c, s)
+				rotateRows(a, p, q, c, s)
+				rotateColumns(v, p, q, c, s)
+			}
+		}
+	}
+	diag := make([]float64, n)
+	for i := range diag {
+		diag[i] = real(a[i][i][0])
+	}
+	order := decreasingOrder(diag)
+	vals = make([]float64, n)
+	for j, k := range order {
+		vals[j] = diag[k]
+	}
+	return vals, permuteColumns(v, order)
+}
+
+// columnQuad returns the sum of the quadrances of the entries of column j of
+// a.
+func columnQuad(a HamiltonMatrix, j int) float64 {
+	var s float64
+	for i := range a {
+		s += a[i][j].Quad()
+	}
+	return s
+}
+
+// columnDot returns the inner product of the columns p and q of a.
+func columnDot(a HamiltonMatrix, p, q int) *Hamilton {
+	s := new(Hamilton)
+	for i := range a {
+		s.Add(s, new(Hamilton).Mul(new(Hamilton).Conj(a[i][p]), a[i][q]))
+	}
+	return s
+}
+
+// scaleColumn multiplies column j of a on the right by z.
+func scaleColumn(a HamiltonMatrix, j int, z *Hamilton) {
+	for i := range a {
+		a[i][j].Mul(a[i][j], z)
+	}
+}
+
+// scaleRow multiplies row i of a on the left by z.
+func scaleRow(a HamiltonMatrix, i int, z *Hamilton) {
+	for j := range a[i] {
+		a[i][j].Mul(z, a[i][j])
+	}
+}
+
+// rotateColumns replaces the columns p and q of a with c*a_p - s*a_q and
+// s*a_p + c*a_q.
+func rotateColumns(a HamiltonMatrix, p, q int, c, s float64) {
+	for i := range a {
+		x, y := new(Hamilton).Copy(a[i][p]), new(Hamilton).Copy(a[i][q])
+		a[i][p].Sub(new(Hamilton).Dil(x, c), new(Hamilton).Dil(y, s))
+		a[i][q].Add(new(Hamilton).Dil(x, s), new(Hamilton).Dil(y, c))
+	}
+}
+
+// rotateRows replaces the rows p and q of a with c*a_p - s*a_q and
+// s*a_p + c*a_q.
+func rotateRows(a HamiltonMatrix, p, q int, c, s float64) {
+	for j := range a[p] {
+		x, y := new(Hamilton).Copy(a[p][j]), new(Hamilton).Copy(a[q][j])
+		a[p][j].Sub(new(Hamilton).Dil(x, c), new(Hamilton).Dil(y, s))
+		a[q][j].Add(new(Hamilton).Dil(x, s), new(Hamilton).Dil(y, c))
+	}
+}
+
+// decreasingOrder returns the indices of x sorted by decreasing value.
+func decreasingOrder(x []float64) []int {
+	order := make([]int, len(x))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return x[order[i]] > x[order[j]]
+	})
+	return order
+}
+
+// permuteColumns returns the matrix whose column j is column order[j] of a.
+func permuteColumns(a HamiltonMatrix, order []int) HamiltonMatrix {
+	b := NewHamiltonMatrix(len(a), len(order))
+	for i := range a {
+		for j, k := range order {
+			b[i][j].Copy(a[i][k])
+		}
+	}
+	return b
+}
+
+// completeColumn replaces column j of a with a unit vector orthogonal to its
+// columns 0, ..., j-1, which must be orthonormal.
+func completeColumn(a HamiltonMatrix, j int) {
+	for k := range a {
+		w := NewHamiltonVector(len(a))
+		w[k] = NewHamilton(1, 0, 0, 0)
+		for l := 0; l < j; l++ {
+			col := make(HamiltonVector, len(a))
+			for i := range a {
+				col[i] = a[i][l]
+			}
+			p := col.Dot(w)
+			for i := range w {
+				w[i].Sub(w[i], new(Hamilton).Mul(col[i], p))
+			}
+		}
+		if n := w.Norm(); n > 0.5 {
+			for i := range a {
+				a[i][j].Dil(w[i], 1/n)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "testing"
+
+// diagHamiltonMatrix returns the square diagonal matrix with real entries d.
+func diagHamiltonMatrix(d []float64) HamiltonMatrix {
+	m := NewHamiltonMatrix(len(d), len(d))
+	for i, x := range d {
+		m[i][i] = NewHamilton(x, 0, 0, 0)
+	}
+	return m
+}
+
+func TestHamiltonMatrixSVD(t *testing.T) {
+	tall := append(testHamiltonMatrix(), HamiltonVector{NewHamilton(1, 1, 0, 0), new(Hamilton), NewHamilton(0, 0, 2, 0)})
+	rank1 := HamiltonVector{NewHamilton(1, 2, 0, 0), NewHamilton(0, 0, 1, -1)}
+	var tests = []HamiltonMatrix{
+		testHamiltonMatrix(),
+		tall,
+		tall.ConjTranspose(),
+		{rank1, rank1.ScaleLeft(NewHamilton(0, 0, 3, 0))},
+	}
+	for _, m := range tests {
+		u, s, v := m.SVD()
+		r, c := m.Dims()
+		k := len(s)
+		if k != r && k != c {
+			t.Errorf("SVD returned %d singular values for a %d×%d matrix", k, r, c)
+		}
+		for j := 1; j < k; j++ {
+			if s[j] > s[j-1] {
+				t.Errorf("singular values %v are not decreasing", s)
+			}
+		}
+		if p := u.Mul(diagHamiltonMatrix(s)).Mul(v.ConjTranspose()); !closeHamiltonMatrix(p, m) {
+			t.Errorf("u*s*v* = %v, want %v", p, m)
+		}
+		id := IdentityHamiltonMatrix(k)
+		if p := u.ConjTranspose().Mul(u); !closeHamiltonMatrix(p, id) {
+			t.Errorf("u*u = %v, want 1", p)
+		}
+		if p := v.ConjTranspose().Mul(v); !closeHamiltonMatrix(p, id) {
+			t.Errorf("v*v = %v, want 1", p)
+		}
+	}
+}
+
+func TestHamiltonMatrixHermitianEigen(t *testing.T) {
+	a := testHamiltonMatrix()
+	m := a.ConjTranspose().Mul(a)
+	vals, vecs := m.HermitianEigen()
+	if p := vecs.Mul(diagHamiltonMatrix(vals)).Mul(vecs.ConjTranspose()); !closeHamiltonMatrix(p, m) {
+		t.Errorf("v*d*v* = %v, want %v", p, m)
+	}
+	if p := vecs.ConjTranspose().Mul(vecs); !closeHamiltonMatrix(p, IdentityHamiltonMatrix(3)) {
+		t.Errorf("v*v = %v, want 1", p)
+	}
+	// The eigenvalues of a*a are the squares of the singular values of a.
+	_, s, _ := a.SVD()
+	for j := range vals {
+		if notEquals(vals[j], s[j]*s[j]) {
+			t.Errorf("eigenvalue %d = %v, want %v", j, vals[j], s[j]*s[j])
+		}
+	}
+}
+
+func TestHamiltonMatrixHermitianEigenPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("HermitianEigen of a non-Hermitian matrix did not panic")
+		}
+	}()
+	testHamiltonMatrix().HermitianEigen()
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// A Vec3 represents a vector in three-dimensional space as an ordered array of
+// three float64 values.
+type Vec3 [3]float64
+
+// Add returns the sum of v and w.
+func (v Vec3) Add(w Vec3) Vec3 {
+	return Vec3{v[0] + w[0], v[1] + w[1], v[2] + w[2]}
+}
+
+// Sub returns the difference of v and w.
+func (v Vec3) Sub(w Vec3) Vec3 {
+	return Vec3{v[0] - w[0], v[1] - w[1], v[2] - w[2]}
+}
+
+// Scale returns v scaled by a.
+func (v Vec3) Scale(a float64) Vec3 {
+	return Vec3{a * v[0], a * v[1], a * v[2]}
+}
+
+// Dot returns the dot product of v and w.
+func (v Vec3) Dot(w Vec3) float64 {
+	return (v[0] * w[0]) + (v[1] * w[1]) + (v[2] * w[2])
+}
+
+// Cross returns the cross product of v and w.
+func (v Vec3) Cross(w Vec3) Vec3 {
+	return Vec3{
+		(v[1] * w[2]) - (v[2] * w[1]),
+		(v[2] * w[0]) - (v[0] * w[2]),
+		(v[0] * w[1]) - (v[1] * w[0]),
+	}
+}
+
+// Norm returns the Euclidean length of v.
+func (v Vec3) Norm() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Unit returns v divided by its length. If v is zero, then Unit panics.
+func (v Vec3) Unit() Vec3 {
+	n := v.Norm()
+	if n == 0 {
+		panic("unit vector of zero")
+	}
+	return v.Scale(1 / n)
+}
+
+// A Mat3 represents a 3×3 matrix as an ordered array of three rows.
+type Mat3 [3][3]float64
+
+// Identity3 returns the 3×3 identity matrix.
+func Identity3() Mat3 {
+	return Mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+}
+
+// Skew returns the matrix of the linear map w ↦ Cross(v, w).
+func Skew(v Vec3) Mat3 {
+	return Mat3{
+		{0, -v[2], v[1]},
+		{v[2], 0, -v[0]},
+		{-v[1], v[0], 0},
+	}
+}
+
+// Outer returns the outer product of v and w.
+func Outer(v, w Vec3) Mat3 {
+	var m Mat3
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = v[i] * w[j]
+		}
+	}
+	return m
+}
+
+// Add returns the sum of m and n.
+func (m Mat3) Add(n Mat3) Mat3 {
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] += n[i][j]
+		}
+	}
+	return m
+}
+
+// Sub returns the difference of m and n.
+func (m Mat3) Sub(n Mat3) Mat3 {
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] -= n[i][j]
+		}
+	}
+	return m
+}
+
+// Scale returns m scaled by a.
+func (m Mat3) Scale(a float64) Mat3 {
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] *= a
+		}
+	}
+	return m
+}
+
+// Mul returns the product of m and n.
+func (m Mat3) Mul(n Mat3) Mat3 {
+	var p Mat3
+	for i := range p {
+		for j := range p[i] {
+			for k := range n {
+				p[i][j] += m[i][k] * n[k][j]
+			}
+		}
+	}
+	return p
+}
+
+// MulVec returns the product of m and v.
+func (m Mat3) MulVec(v Vec3) Vec3 {
+	return Vec3{
+		Vec3(m[0]).Dot(v),
+		Vec3(m[1]).Dot(v),
+		Vec3(m[2]).Dot(v),
+	}
+}
+
+// Transpose returns the transpose of m.
+func (m Mat3) Transpose() Mat3 {
+	for i := range m {
+		for j := i + 1; j < 3; j++ {
+			m[i][j], m[j][i] = m[j][i], m[i][j]
+		}
+	}
+	return m
+}
+
+// Trace returns the sum of the diagonal entries of m.
+func (m Mat3) Trace() float64 {
+	return m[0][0] + m[1][1] + m[2][2]
+}
+
+// Det returns the determinant of m.
+func (m Mat3) Det() float64 {
+	return Vec3(m[0]).Dot(Vec3(m[1]).Cross(Vec3(m[2])))
+}
+
+// Inv returns the inverse of m. If m is singular, then Inv panics.
+func (m Mat3) Inv() Mat3 {
+	det := m.Det()
+	if det == 0 {
+		panic("inverse of singular matrix")
+	}
+	a, b, c := Vec3(m[0]), Vec3(m[1]), Vec3(m[2])
+	// The columns of the adjugate are the pairwise cross products of the rows.
+	adj := Mat3{b.Cross(c), c.Cross(a), a.Cross(b)}.Transpose()
+	return adj.Scale(1 / det)
+}
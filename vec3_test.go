@@ -0,0 +1,28 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "testing"
+
+func TestVec3Cross(t *testing.T) {
+	var tests = []struct {
+		v, w, want Vec3
+	}{
+		{Vec3{1, 0, 0}, Vec3{0, 1, 0}, Vec3{0, 0, 1}},
+		{Vec3{0, 1, 0}, Vec3{0, 0, 1}, Vec3{1, 0, 0}},
+		{Vec3{1, 2, 3}, Vec3{1, 2, 3}, Vec3{}},
+	}
+	for _, tt := range tests {
+		if got := tt.v.Cross(tt.w); got != tt.want {
+			t.Errorf("Cross(%v, %v) = %v, want %v", tt.v, tt.w, got, tt.want)
+		}
+	}
+}
+
+func TestVec3Unit(t *testing.T) {
+	got := (Vec3{3, 0, 4}).Unit()
+	if notEquals(got[0], 0.6) || notEquals(got[1], 0) || notEquals(got[2], 0.8) {
+		t.Errorf("Unit = %v, want (0.6, 0, 0.8)", got)
+	}
+}
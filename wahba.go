@@ -0,0 +1,211 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import "math"
+
+// An Observation represents a weighted pair of vector observations: a vector
+// known in the reference frame and the same vector measured in the body
+// frame.
+type Observation struct {
+	Ref, Body Vec3
+	Weight    float64
+}
+
+// A WahbaSolution represents the solution of Wahba's problem. Q is the unit
+// Hamilton value that best rotates reference vectors into body vectors (i.e.
+// Body ≈ Q.Rotate(Ref)), and Loss is the value of the loss function
+//
+//	½ Σ Weight |Body - Q.Rotate(Ref)|²
+//
+// at Q. If each Weight equals 1/σ², with σ the angular noise (in radians) of
+// the corresponding observation, then Cov approximates the covariance of the
+// small rotation error in the body frame.
+type WahbaSolution struct {
+	Q    *Hamilton
+	Loss float64
+	Cov  Mat3
+}
+
+// wahbaMatrix returns the symmetric 4×4 Davenport matrix K of a set of
+// observations, such that the quadratic form of K at a unit Hamilton value q
+// (with components ordered as 1, i, j, k) equals
+//
+//	Σ Weight Dot(Body, q.Rotate(Ref))
+//
+// It also returns the constant ½ Σ Weight (|Body|² + |Ref|²). If obs is
+// empty, then wahbaMatrix panics.
+func wahbaMatrix(obs []Observation) (k [4][4]float64, λ0 float64) {
+	if len(obs) == 0 {
+		panic("observations do not determine attitude")
+	}
+	var s Mat3
+	for _, o := range obs {
+		s = s.Add(Outer(o.Ref, o.Body).Scale(o.Weight))
+		λ0 += o.Weight * (o.Ref.Dot(o.Ref) + o.Body.Dot(o.Body)) / 2
+	}
+	k = [4][4]float64{
+		{s[0][0] + s[1][1] + s[2][2], s[1][2] - s[2][1], s[2][0] - s[0][2], s[0][1] - s[1][0]},
+		{s[1][2] - s[2][1], s[0][0] - s[1][1] - s[2][2], s[0][1] + s[1][0], s[2][0] + s[0][2]},
+		{s[2][0] - s[0][2], s[0][1] + s[1][0], -s[0][0] + s[1][1] - s[2][2], s[1][2] + s[2][1]},
+		{s[0][1] - s[1][0], s[2][0] + s[0][2], s[1][2] + s[2][1], -s[0][0] - s[1][1] + s[2][2]},
+	}
+	return
+}
+
+// wahbaSolution returns the solution for the optimal q, given the largest
+// eigenvalue λ of the Davenport matrix.
+func wahbaSolution(obs []Observation, q *Hamilton, λ, λ0 float64) *WahbaSolution {
+	var info Mat3
+	for _, o := range obs {
+		b := o.Body.Unit()
+		info = info.Add(Identity3().Sub(Outer(b, b)).Scale(o.Weight))
+	}
+	if t := info.Trace(); t <= 0 || math.Abs(info.Det()) < delta*math.Pow(t, 3) {
+		panic("observations do not determine attitude")
+	}
+	return &WahbaSolution{
+		Q:    positive(q.Normalize(q)),
+		Loss: math.Max(λ0-λ, 0),
+		Cov:  info.Inv(),
+	}
+}
+
+// WahbaDavenport returns the solution of Wahba's problem for a set of
+// observations, found as the eigenvector of the largest eigenvalue of
+// Davenport's K matrix. If the observations do not determine the attitude
+// (e.g. if all vectors are parallel), then WahbaDavenport panics.
+func WahbaDavenport(obs []Observation) *WahbaSolution {
+	k, λ0 := wahbaMatrix(obs)
+	m := make([][]float64, 4)
+	for i := range m {
+		m[i] = k[i][:]
+	}
+	vals, vecs := symEigen(m)
+	q := NewHamilton(vecs[0][0], vecs[1][0], vecs[2][0], vecs[3][0])
+	return wahbaSolution(obs, q, vals[0], λ0)
+}
+
+// wahbaEigenvalue returns the largest eigenvalue of k, found with Newton's
+// method on the characteristic polynomial starting from λ0.
+func wahbaEigenvalue(k [4][4]float64, λ0 float64) float64 {
+	// Faddeev-LeVerrier coefficients of det(λI - k) = λ⁴ + c[3]λ³ + ... + c[0].
+	var c [4]float64
+	var m, n [4][4]float64
+	for i := range m {
+		m[i][i] = 1
+	}
+	for d := 1; d <= 4; d++ {
+		var tr float64
+		for i := range n {
+			for j := range n[i] {
+				n[i][j] = 0
+				for l := range k {
+					n[i][j] += k[i][l] * m[l][j]
+				}
+			}
+			tr += n[i][i]
+		}
+		c[4-d] = -tr / float64(d)
+		m = n
+		for i := range m {
+			m[i][i] += c[4-d]
+		}
+	}
+	λ := λ0
+	for iter := 0; iter < 50; iter++ {
+		p := (((λ+c[3])*λ+c[2])*λ+c[1])*λ + c[0]
+		dp := ((4*λ+3*c[3])*λ+2*c[2])*λ + c[1]
+		if dp == 0 {
+			break
+		}
+		step := p / dp
+		λ -= step
+		if math.Abs(step) <= 1e-15*math.Abs(λ) {
+			break
+		}
+	}
+	return λ
+}
+
+// esoqVector returns a vector in the null space of k - λI, found as the
+// largest of the generalized cross products of three of its rows.
+func esoqVector(k [4][4]float64, λ float64) *Hamilton {
+	h := k
+	for i := range h {
+		h[i][i] -= λ
+	}
+	var best [4]float64
+	var bestNorm float64
+	for skip := 0; skip < 4; skip++ {
+		var rows [3][4]float64
+		r := 0
+		for i := range h {
+			if i != skip {
+				rows[r] = h[i]
+				r++
+			}
+		}
+		var v [4]float64
+		var norm float64
+		for col := 0; col < 4; col++ {
+			var minor Mat3
+			for i := range rows {
+				c := 0
+				for j := range rows[i] {
+					if j != col {
+						minor[i][c] = rows[i][j]
+						c++
+					}
+				}
+			}
+			v[col] = minor.Det()
+			if col%2 == 1 {
+				v[col] = -v[col]
+			}
+			norm += v[col] * v[col]
+		}
+		if norm > bestNorm {
+			best, bestNorm = v, norm
+		}
+	}
+	return NewHamilton(best[0], best[1], best[2], best[3])
+}
+
+// WahbaQUEST returns the solution of Wahba's problem for a set of
+// observations, found with the QUEST algorithm: the largest eigenvalue of
+// Davenport's K matrix is computed with Newton's method on its
+// characteristic polynomial, and the attitude follows from the Gibbs vector.
+// Near a rotation by π, where the Gibbs vector diverges, it falls back to the
+// eigenvector construction of WahbaESOQ. If the observations do not determine
+// the attitude, then WahbaQUEST panics.
+func WahbaQUEST(obs []Observation) *WahbaSolution {
+	k, λ0 := wahbaMatrix(obs)
+	λ := wahbaEigenvalue(k, λ0)
+	var m Mat3
+	var b Vec3
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = k[i+1][j+1]
+		}
+		m[i][i] -= λ
+		b[i] = -k[i+1][0]
+	}
+	if math.Abs(m.Det()) <= delta*math.Pow(λ0, 3) {
+		return wahbaSolution(obs, esoqVector(k, λ), λ, λ0)
+	}
+	g := m.Inv().MulVec(b)
+	return wahbaSolution(obs, NewHamilton(1, g[0], g[1], g[2]), λ, λ0)
+}
+
+// WahbaESOQ returns the solution of Wahba's problem for a set of
+// observations, found with the ESOQ algorithm: the largest eigenvalue of
+// Davenport's K matrix is computed as in WahbaQUEST, and the attitude is the
+// generalized cross product of three rows of K - λI. If the observations do
+// not determine the attitude, then WahbaESOQ panics.
+func WahbaESOQ(obs []Observation) *WahbaSolution {
+	k, λ0 := wahbaMatrix(obs)
+	λ := wahbaEigenvalue(k, λ0)
+	return wahbaSolution(obs, esoqVector(k, λ), λ, λ0)
+}
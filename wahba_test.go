@@ -0,0 +1,98 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package quat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// noisyObservations returns n observations of random unit vectors rotated by
+// q, with isotropic noise of size σ added to the body vectors.
+func noisyObservations(r *rand.Rand, q *Hamilton, n int, σ float64) []Observation {
+	obs := make([]Observation, n)
+	for i := range obs {
+		ref := Vec3{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}.Unit()
+		noise := Vec3{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}.Scale(σ)
+		obs[i] = Observation{
+			Ref:    ref,
+			Body:   q.Rotate(ref).Add(noise).Unit(),
+			Weight: 1 / (σ * σ),
+		}
+	}
+	return obs
+}
+
+func TestWahba(t *testing.T) {
+	solvers := map[string]func([]Observation) *WahbaSolution{
+		"WahbaDavenport": WahbaDavenport,
+		"WahbaQUEST":     WahbaQUEST,
+		"WahbaESOQ":      WahbaESOQ,
+	}
+	r := rand.New(rand.NewSource(3))
+	σ := 1e-3
+	for _, q := range []*Hamilton{
+		RotationHamilton(Vec3{1, 2, 3}, 0.7),
+		RotationHamilton(Vec3{-1, 0, 2}, 2.9),
+		RotationHamilton(Vec3{0, 1, 0}, math.Pi),
+	} {
+		obs := noisyObservations(r, q, 10, σ)
+		for name, solve := range solvers {
+			s := solve(obs)
+			if θ := angleBetweenRotations(s.Q, q); θ > 5*σ {
+				t.Errorf("%s is %v away from %v", name, θ, q)
+			}
+			var want float64
+			for _, o := range obs {
+				d := o.Body.Sub(s.Q.Rotate(o.Ref))
+				want += o.Weight * d.Dot(d) / 2
+			}
+			if math.Abs(s.Loss-want) > 1e-6*want {
+				t.Errorf("%s loss = %v, want %v", name, s.Loss, want)
+			}
+			for i := range s.Cov {
+				if s.Cov[i][i] <= 0 || s.Cov[i][i] > σ*σ {
+					t.Errorf("%s covariance has diagonal entry %v", name, s.Cov[i][i])
+				}
+			}
+		}
+	}
+}
+
+func TestWahbaParallel(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("WahbaDavenport did not panic on parallel observations")
+		}
+	}()
+	WahbaDavenport([]Observation{
+		{Vec3{1, 0, 0}, Vec3{0, 1, 0}, 1},
+		{Vec3{2, 0, 0}, Vec3{0, 2, 0}, 1},
+	})
+}
+
+func TestWahbaUndetermined(t *testing.T) {
+	zero := []Observation{
+		{Vec3{1, 0, 0}, Vec3{0, 1, 0}, 0},
+		{Vec3{0, 1, 0}, Vec3{0, 0, 1}, 0},
+	}
+	solvers := map[string]func([]Observation) *WahbaSolution{
+		"WahbaDavenport": WahbaDavenport,
+		"WahbaQUEST":     WahbaQUEST,
+		"WahbaESOQ":      WahbaESOQ,
+	}
+	for name, solve := range solvers {
+		for _, obs := range [][]Observation{nil, zero} {
+			func() {
+				defer func() {
+					if r := recover(); r != "observations do not determine attitude" {
+						t.Errorf("%s of %d observations panicked with %v", name, len(obs), r)
+					}
+				}()
+				solve(obs)
+			}()
+		}
+	}
+}